@@ -0,0 +1,182 @@
+package condition
+
+import "math/bits"
+
+// Fixed-size set of element indices, one bit per element of a slice.
+//
+// Copies of a bitset share their elements, use Clone to change one without changing the others.
+type Bitset struct {
+	words []uint64
+	n     int
+}
+
+const wordSize = 64
+
+// Create an empty bitset for a slice of n elements.
+func NewBitset(n int) Bitset {
+	return Bitset{
+		words: make([]uint64, (n+wordSize-1)/wordSize),
+		n:     n,
+	}
+}
+
+// Create a bitset for a slice of n elements, with every element in the set.
+func NewFullBitset(n int) Bitset {
+	b := NewBitset(n)
+	for i := range b.words {
+		b.words[i] = ^uint64(0)
+	}
+
+	b.clearTail()
+
+	return b
+}
+
+// Obtain the number of elements of the bitset.
+func (b Bitset) Len() int {
+	return b.n
+}
+
+// Report whether the element at the given index is in the set.
+func (b Bitset) Test(i int) bool {
+	return b.words[i/wordSize]&(1<<(uint(i)%wordSize)) != 0
+}
+
+// Add the element at the given index to the set.
+func (b Bitset) Set(i int) {
+	b.words[i/wordSize] |= 1 << (uint(i) % wordSize)
+}
+
+// Obtain the number of elements in the set.
+func (b Bitset) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+
+	return count
+}
+
+// Copy the set, so that it can be changed without changing the original.
+func (b Bitset) Clone() Bitset {
+	c := Bitset{
+		words: make([]uint64, len(b.words)),
+		n:     b.n,
+	}
+	copy(c.words, b.words)
+
+	return c
+}
+
+// Only keep the elements also in the other set, in place.
+func (b Bitset) And(o Bitset) {
+	for i := range b.words {
+		b.words[i] &= o.words[i]
+	}
+}
+
+// Add the elements of the other set, in place.
+func (b Bitset) Or(o Bitset) {
+	for i := range b.words {
+		b.words[i] |= o.words[i]
+	}
+}
+
+// Remove the elements of the other set, in place.
+func (b Bitset) AndNot(o Bitset) {
+	for i := range b.words {
+		b.words[i] &^= o.words[i]
+	}
+}
+
+// Report whether no element is in the set.
+func (b Bitset) none() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Obtain the first element in the set from the given index, or -1 if there is none.
+func (b Bitset) next(i int) int {
+	if i >= b.n {
+		return -1
+	}
+
+	w := i / wordSize
+	word := b.words[w] >> (uint(i) % wordSize) << (uint(i) % wordSize)
+	for {
+		if word != 0 {
+			return w*wordSize + bits.TrailingZeros64(word)
+		}
+
+		w++
+		if w >= len(b.words) {
+			return -1
+		}
+
+		word = b.words[w]
+	}
+}
+
+// Obtain the set where each element i is in it if element i+offset is in the original set.
+// Elements whose i+offset is outside of the slice are in it if fill is true.
+func (b Bitset) shift(offset int, fill bool) Bitset {
+	s := NewBitset(b.n)
+
+	q, r := offset/wordSize, uint(offset%wordSize)
+	if offset < 0 {
+		q, r = -(-offset / wordSize), uint(-offset%wordSize)
+	}
+
+	for w := range s.words {
+		if offset >= 0 {
+			s.words[w] = b.word(w+q) >> r
+			if r != 0 {
+				s.words[w] |= b.word(w+q+1) << (wordSize - r)
+			}
+		} else {
+			s.words[w] = b.word(w+q) << r
+			if r != 0 {
+				s.words[w] |= b.word(w+q-1) >> (wordSize - r)
+			}
+		}
+	}
+
+	s.clearTail()
+
+	if fill {
+		// Only the first -offset or last offset elements are outside.
+		low, high := 0, b.n
+		if offset > 0 && offset < b.n {
+			low = b.n - offset
+		} else if offset < 0 && -offset < b.n {
+			high = -offset
+		}
+
+		for i := low; i < high; i++ {
+			s.Set(i)
+		}
+	}
+
+	return s
+}
+
+// Obtain the word at the given position, which is empty if it is outside of the set.
+func (b Bitset) word(w int) uint64 {
+	if w < 0 || w >= len(b.words) {
+		return 0
+	}
+
+	return b.words[w]
+}
+
+// Remove the bits past the last element.
+func (b Bitset) clearTail() {
+	if r := uint(b.n % wordSize); r != 0 {
+		b.words[len(b.words)-1] &= 1<<r - 1
+	}
+}
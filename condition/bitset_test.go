@@ -0,0 +1,164 @@
+package condition_test
+
+import (
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestSliceShortCircuit(t *testing.T) {
+	values := toInterfaces(dummyIntValues)
+
+	// Each element is tested by the subconditions of And and Or as many times as with Test.
+	for _, newCond := range []func(condition.Condition, condition.Condition) condition.Condition{
+		func(a condition.Condition, b condition.Condition) condition.Condition { return condition.And(a, b) },
+		func(a condition.Condition, b condition.Condition) condition.Condition { return condition.Or(a, b) },
+		func(a condition.Condition, b condition.Condition) condition.Condition {
+			return condition.And(condition.Not(a), condition.Next(b))
+		},
+	} {
+		calls := make([]int, 2)
+		newCounted := func(k int, fn condition.CheckFunc) condition.Condition {
+			return condition.Check(func(x interface{}) bool {
+				calls[k]++
+				return fn(x)
+			})
+		}
+
+		c := newCond(newCounted(0, condition.Gt(300)), newCounted(1, condition.Lt(100)))
+		for i := range values {
+			c.Test(condition.MatchContext{Values: values, CurrentIndex: i})
+		}
+
+		expected := append([]int(nil), calls...)
+		calls[0], calls[1] = 0, 0
+
+		condition.TestSlice(c, condition.MatchContext{Values: values}, condition.NewFullBitset(len(values)))
+		assert.Equal(t, expected, calls)
+	}
+}
+
+func TestBitset(t *testing.T) {
+	b := condition.NewBitset(130)
+	b.Set(0)
+	b.Set(64)
+	b.Set(129)
+
+	assert.Equal(t, 130, b.Len())
+	assert.Equal(t, 3, b.Count())
+	assert.True(t, b.Test(0))
+	assert.True(t, b.Test(64))
+	assert.True(t, b.Test(129))
+	assert.False(t, b.Test(1))
+	assert.False(t, b.Test(128))
+
+	full := condition.NewFullBitset(130)
+	assert.Equal(t, 130, full.Count())
+
+	c := full.Clone()
+	c.AndNot(b)
+	assert.Equal(t, 127, c.Count())
+	assert.Equal(t, 130, full.Count())
+	assert.False(t, c.Test(64))
+
+	c.Or(b)
+	assert.Equal(t, 130, c.Count())
+
+	c.And(b)
+	assert.Equal(t, 3, c.Count())
+}
+
+func TestTestSliceEquivalent(t *testing.T) {
+	values := make([]interface{}, 200)
+	for i := range values {
+		values[i] = (i * 37) % 11
+	}
+
+	small := condition.Check(condition.Lt(4))
+	even := condition.Check(func(x interface{}) bool {
+		return x.(int)%2 == 0
+	})
+
+	conds := map[string]condition.Condition{
+		"Check": small,
+		"And":   condition.And(small, even),
+		"Or":    condition.Or(small, even),
+		"Not":   condition.Not(condition.And(small, even)),
+		"Prev":  condition.Prev(small),
+		"Next":  condition.Next(small, condition.WithOutOfBounds(true)),
+		"At":    condition.At(-70, even, condition.WithOutOfBounds(true)),
+		"AtFar": condition.At(130, small),
+		"AtOut": condition.At(-300, small, condition.WithOutOfBounds(true)),
+
+		"LookBeforeAny": condition.LookBeforeAny(condition.Check(condition.Eq(10))),
+		"LookAfterAll":  condition.LookAfterAll(condition.Not(condition.Check(condition.Eq(3)))),
+		"LookaroundP": condition.LookaroundP(
+			condition.And(small, even),
+			-2,
+			condition.WithMaxDist(9),
+			condition.WithStartDist(2),
+			condition.WithAtLeast(1),
+			condition.WithAtMost(2),
+		),
+		"Lookaround": condition.Lookaround(
+			func(x interface{}) condition.Condition {
+				return condition.Check(condition.Eq(x))
+			},
+			1,
+			condition.WithMaxDist(11),
+		),
+		"Within": condition.Within(
+			condition.Check(condition.Eq(0)),
+			condition.Check(condition.Eq(5)),
+			condition.WithNested(true),
+		),
+		"Memo":  condition.Memo(condition.Or(small, condition.Prev(even))),
+		"Mixed": condition.And(condition.Memo(small), condition.Not(condition.LookBeforeAny(condition.Memo(small)))),
+	}
+
+	for name, c := range conds {
+		for _, cache := range []*condition.Cache{nil, condition.NewCache()} {
+			b := condition.TestSlice(c, condition.MatchContext{Values: values, Cache: cache}, condition.NewFullBitset(len(values)))
+			assert.Equal(t, len(values), b.Len(), name)
+
+			for i := range values {
+				expected := c.Test(condition.MatchContext{Values: values, CurrentIndex: i})
+				assert.Equal(t, expected, b.Test(i), "%s, Cached: %t, Index: %d", name, cache != nil, i)
+			}
+
+			// Elements outside of the mask are never in the result.
+			mask := condition.NewBitset(len(values))
+			for i := 0; i < len(values); i += 3 {
+				mask.Set(i)
+			}
+
+			b = condition.TestSlice(c, condition.MatchContext{Values: values, Cache: cache}, mask)
+			for i := range values {
+				expected := i%3 == 0 && c.Test(condition.MatchContext{Values: values, CurrentIndex: i})
+				assert.Equal(t, expected, b.Test(i), "%s, Cached: %t, Masked, Index: %d", name, cache != nil, i)
+			}
+		}
+	}
+}
+
+func TestTestSliceMemoShared(t *testing.T) {
+	values := toInterfaces(dummyIntValues)
+
+	calls := 0
+	memo := condition.Memo(condition.Check(func(x interface{}) bool {
+		calls++
+		return x.(int) > 300
+	}))
+
+	cache := condition.NewCache()
+	mask := condition.NewFullBitset(len(values))
+	a := condition.TestSlice(condition.Not(memo), condition.MatchContext{Values: values, Cache: cache}, mask)
+	b := condition.TestSlice(memo, condition.MatchContext{Values: values, Cache: cache}, mask)
+
+	for i := range values {
+		assert.NotEqual(t, a.Test(i), b.Test(i), "Index: %d", i)
+	}
+
+	assert.Equal(t, len(values), calls)
+}
@@ -1,25 +1,30 @@
 package condition
 
 type (
-	orCond  []Condition
-	andCond []Condition
-	notCond struct {
-		cond Condition
+	orCond[T any]  []ConditionOf[T]
+	andCond[T any] []ConditionOf[T]
+	notCond[T any] struct {
+		cond ConditionOf[T]
 	}
 )
 
 // Matches to true if any of the subconditions matches to true.
 func Or(conds ...Condition) Condition {
+	return OrOf(conds...)
+}
+
+// Typed variant of Or.
+func OrOf[T any](conds ...ConditionOf[T]) ConditionOf[T] {
 	if len(conds) == 0 {
 		panic(ErrEmptyCond)
 	}
 
-	return orCond(conds)
+	return orCond[T](conds)
 }
 
-func (c orCond) Test(mctx MatchContext) bool {
+func (c orCond[T]) Test(mctx MatchContextOf[T]) bool {
 	for _, cond := range c {
-		if cond.Test(mctx) {
+		if testCapturing(mctx, cond.Test) {
 			return true
 		}
 	}
@@ -27,16 +32,45 @@ func (c orCond) Test(mctx MatchContext) bool {
 	return false
 }
 
+func (c orCond[T]) TestSlice(mctx MatchContextOf[T], mask Bitset) Bitset {
+	b := TestSliceOf(c[0], mctx, mask)
+
+	// Like Test, the remaining subconditions are only tested on the elements which did not match yet.
+	rest := mask.Clone()
+	rest.AndNot(b)
+
+	for _, cond := range c[1:] {
+		if rest.none() {
+			break
+		}
+
+		matched := TestSliceOf(cond, mctx, rest)
+		b.Or(matched)
+		rest.AndNot(matched)
+	}
+
+	return b
+}
+
 // Matches to true if all of the subconditions matches to true.
 func And(conds ...Condition) Condition {
+	return AndOf(conds...)
+}
+
+// Typed variant of And.
+func AndOf[T any](conds ...ConditionOf[T]) ConditionOf[T] {
 	if len(conds) == 0 {
 		panic(ErrEmptyCond)
 	}
 
-	return andCond(conds)
+	return andCond[T](conds)
+}
+
+func (c andCond[T]) Test(mctx MatchContextOf[T]) bool {
+	return testCapturing(mctx, c.test)
 }
 
-func (c andCond) Test(mctx MatchContext) bool {
+func (c andCond[T]) test(mctx MatchContextOf[T]) bool {
 	for _, cond := range c {
 		if !cond.Test(mctx) {
 			return false
@@ -46,11 +80,38 @@ func (c andCond) Test(mctx MatchContext) bool {
 	return true
 }
 
+func (c andCond[T]) TestSlice(mctx MatchContextOf[T], mask Bitset) Bitset {
+	b := TestSliceOf(c[0], mctx, mask)
+
+	// Like Test, the remaining subconditions are only tested on the elements which still match.
+	for _, cond := range c[1:] {
+		if b.none() {
+			break
+		}
+
+		b = TestSliceOf(cond, mctx, b)
+	}
+
+	return b
+}
+
 // Negates the subcondition's matching result.
 func Not(cond Condition) Condition {
-	return notCond{cond: cond}
+	return NotOf(cond)
+}
+
+// Typed variant of Not.
+func NotOf[T any](cond ConditionOf[T]) ConditionOf[T] {
+	return notCond[T]{cond: cond}
 }
 
-func (c notCond) Test(mctx MatchContext) bool {
-	return !c.cond.Test(mctx)
+func (c notCond[T]) Test(mctx MatchContextOf[T]) bool {
+	return !c.cond.Test(mctx.withCaptures(nil))
+}
+
+func (c notCond[T]) TestSlice(mctx MatchContextOf[T], mask Bitset) Bitset {
+	b := mask.Clone()
+	b.AndNot(TestSliceOf(c.cond, mctx, mask))
+
+	return b
 }
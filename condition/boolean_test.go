@@ -103,3 +103,31 @@ func TestNot(t *testing.T) {
 
 	testCond(t, c, test)
 }
+
+func TestAndOf(t *testing.T) {
+	c := condition.AndOf(
+		condition.CheckOf(func(x int) bool {
+			return x > 350
+		}),
+		condition.NotOf(
+			condition.CheckOf(func(x int) bool {
+				return x >= 400
+			}),
+		),
+	)
+
+	testCondOf(t, c, dummyIntValues, makeExpectations(len(dummyIntValues), []int{0, 1, 8}))
+}
+
+func TestOrOf(t *testing.T) {
+	c := condition.OrOf(
+		condition.CheckOf(func(x int) bool {
+			return x == dummyIntValues[0]
+		}),
+		condition.CheckOf(func(x int) bool {
+			return x == dummyIntValues[1]
+		}),
+	)
+
+	testCondOf(t, c, dummyIntValues, makeExpectations(len(dummyIntValues), []int{0, 1}))
+}
@@ -0,0 +1,63 @@
+package condition
+
+import "sync"
+
+type (
+	// Storage of the results computed by conditions once per slice,
+	// shared by the match contexts of every element of the slice.
+	// It is safe for concurrent use.
+	Cache struct {
+		mu      sync.Mutex
+		entries map[interface{}]*cacheEntry
+	}
+
+	cacheEntry struct {
+		once  sync.Once
+		value interface{}
+
+		// The value of the panic which stopped the computation, if any.
+		panicked   bool
+		panicValue interface{}
+	}
+)
+
+// Create a new empty cache, to be used for a single slice.
+func NewCache() *Cache {
+	return &Cache{
+		entries: make(map[interface{}]*cacheEntry),
+	}
+}
+
+// Load the value stored for the key, computing and storing it first if there is none.
+// The key must be comparable, such as a pointer to the condition storing the value.
+//
+// The value is computed only once, even if loaded concurrently.
+// If the computation panics, every load of the key panics with the same value.
+func (c *Cache) Load(key interface{}, compute func() interface{}) interface{} {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &cacheEntry{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		// Checked with a flag rather than the recovered value, which is nil for panic(nil).
+		completed := false
+		defer func() {
+			if !completed {
+				entry.panicked, entry.panicValue = true, recover()
+			}
+		}()
+
+		entry.value = compute()
+		completed = true
+	})
+
+	if entry.panicked {
+		panic(entry.panicValue)
+	}
+
+	return entry.value
+}
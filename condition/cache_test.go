@@ -0,0 +1,53 @@
+package condition_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheLoad(t *testing.T) {
+	cache := condition.NewCache()
+
+	computed := 0
+	compute := func() interface{} {
+		computed++
+		return computed
+	}
+
+	assert.Equal(t, 1, cache.Load("a", compute))
+	assert.Equal(t, 1, cache.Load("a", compute))
+	assert.Equal(t, 2, cache.Load("b", compute))
+	assert.Equal(t, 2, computed)
+}
+
+func TestCacheLoadPanic(t *testing.T) {
+	cache := condition.NewCache()
+
+	computed := 0
+	compute := func() interface{} {
+		computed++
+		panic("invalid")
+	}
+
+	// Every load panics with the value of the computation, which is not run again.
+	var wg sync.WaitGroup
+	for k := 0; k < 4; k++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			assert.PanicsWithValue(t, "invalid", func() {
+				cache.Load("a", compute)
+			})
+		}()
+	}
+	wg.Wait()
+
+	assert.PanicsWithValue(t, "invalid", func() {
+		cache.Load("a", compute)
+	})
+	assert.Equal(t, 1, computed)
+}
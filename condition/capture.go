@@ -0,0 +1,80 @@
+package condition
+
+import (
+	"reflect"
+	"regexp"
+)
+
+type (
+	regexCaptureCond[T any] struct {
+		path fieldPath
+		re   *regexp.Regexp
+	}
+
+	// Named values recorded by conditions while being tested.
+	//
+	// Captures recorded by a subcondition are only kept if the subcondition contributes to the match:
+	// captures of a failing And, of the failing branches of Or, and inside Not are discarded.
+	Captures map[string]string
+)
+
+// Matches to true if a string element matches the given regular expression,
+// recording the named groups of the match as captures.
+// The pattern is compiled once and panics if it is invalid.
+func RegexCapture(pattern string) Condition {
+	return RegexCaptureOf[interface{}](pattern)
+}
+
+// Typed variant of RegexCapture.
+func RegexCaptureOf[T any](pattern string) ConditionOf[T] {
+	return regexCaptureCond[T]{
+		re: regexp.MustCompile(pattern),
+	}
+}
+
+// Matches to true if a struct element's value is a string matching the given regular expression,
+// recording the named groups of the match as captures.
+// The target follows the same format as FieldCheck.
+func FieldRegexCapture(target string, pattern string, options ...FieldOption) Condition {
+	return FieldRegexCaptureOf[interface{}](target, pattern, options...)
+}
+
+// Typed variant of FieldRegexCapture.
+func FieldRegexCaptureOf[T any](target string, pattern string, options ...FieldOption) ConditionOf[T] {
+	return regexCaptureCond[T]{
+		path: parseFieldPath(target, options),
+		re:   regexp.MustCompile(pattern),
+	}
+}
+
+func (c regexCaptureCond[T]) Test(mctx MatchContextOf[T]) bool {
+	return testField(mctx, c.path, func(rv reflect.Value) bool {
+		return c.test(mctx, rv)
+	})
+}
+
+func (c regexCaptureCond[T]) test(mctx MatchContextOf[T], rv reflect.Value) bool {
+	for rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.String {
+		return false
+	}
+
+	s := rv.String()
+	loc := c.re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return false
+	}
+
+	for i, name := range c.re.SubexpNames() {
+		if name == "" || loc[2*i] < 0 {
+			continue
+		}
+
+		mctx.Capture(name, s[loc[2*i]:loc[2*i+1]])
+	}
+
+	return true
+}
@@ -0,0 +1,102 @@
+package condition_test
+
+import (
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCaptures(c condition.Condition, values []interface{}, index int) (bool, condition.Captures) {
+	captures := make(condition.Captures)
+	ok := c.Test(condition.MatchContext{
+		Values:       values,
+		CurrentIndex: index,
+		Captures:     captures,
+	})
+
+	return ok, captures
+}
+
+func TestRegexCapture(t *testing.T) {
+	c := condition.RegexCapture(`^dummy (?P<kind>\w+) (?P<num>\d+)(?P<rest> .*)?$`)
+
+	values := []interface{}{
+		dummyStringValues[0],
+		dummyStringValues[1],
+		exampleNamedString("dummy value 3"),
+		dummyIntValues[0],
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 1, 2}),
+	}
+
+	testCond(t, c, test)
+
+	ok, captures := testCaptures(c, values, 0)
+	assert.True(t, ok)
+	assert.Equal(t, condition.Captures{"kind": "value", "num": "1"}, captures)
+
+	ok, captures = testCaptures(c, values, 1)
+	assert.True(t, ok)
+	assert.Equal(t, condition.Captures{"kind": "value", "num": "2", "rest": " (but very long)"}, captures)
+
+	ok, captures = testCaptures(c, values, 3)
+	assert.False(t, ok)
+	assert.Empty(t, captures)
+}
+
+func TestFieldRegexCapture(t *testing.T) {
+	c := condition.FieldRegexCapture("Field2.Field1.Field2", `^sub(?P<name>[a-z]+)(?P<num>\d)$`)
+
+	values := []interface{}{
+		dummyStructValues[0],
+		dummyStructValues[1],
+		dummyRogueStructValue,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0}),
+	}
+
+	testCond(t, c, test)
+
+	ok, captures := testCaptures(c, values, 0)
+	assert.True(t, ok)
+	assert.Equal(t, condition.Captures{"name": "value", "num": "2"}, captures)
+}
+
+func TestCaptureBoolean(t *testing.T) {
+	values := []interface{}{
+		"alpha-1",
+		"beta-2",
+	}
+
+	c := condition.And(
+		condition.RegexCapture(`^(?P<word>[a-z]+)`),
+		condition.Or(
+			condition.And(
+				condition.RegexCapture(`(?P<failed>a)`),
+				condition.Check(condition.Eq("never")),
+			),
+			condition.RegexCapture(`(?P<num>\d)$`),
+		),
+		condition.Not(condition.RegexCapture(`(?P<negated>z)`)),
+	)
+
+	ok, captures := testCaptures(c, values, 0)
+	assert.True(t, ok)
+	assert.Equal(t, condition.Captures{"word": "alpha", "num": "1"}, captures)
+
+	failing := condition.And(
+		condition.RegexCapture(`^(?P<word>[a-z]+)`),
+		condition.Check(condition.Eq("never")),
+	)
+
+	ok, captures = testCaptures(failing, values, 1)
+	assert.False(t, ok)
+	assert.Empty(t, captures)
+}
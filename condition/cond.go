@@ -1,6 +1,55 @@
 package condition
 
-type Condition interface {
-	// Test a condition for an element at a given the match context.
-	Test(mctx MatchContext) bool
+type (
+	ConditionOf[T any] interface {
+		// Test a condition for an element at a given the match context.
+		Test(mctx MatchContextOf[T]) bool
+	}
+
+	Condition = ConditionOf[interface{}]
+
+	// Condition able to test many elements of the slice at once.
+	// Conditions not implementing it are tested element by element.
+	SliceConditionOf[T any] interface {
+		ConditionOf[T]
+
+		// Test a condition for the elements of the slice of the match context which are in the mask,
+		// giving the same results as Test without recording any captures.
+		// Elements outside of the mask are not in the result.
+		//
+		// Like Test, subconditions should only be tested on the elements needed for the result.
+		TestSlice(mctx MatchContextOf[T], mask Bitset) Bitset
+	}
+
+	SliceCondition = SliceConditionOf[interface{}]
+)
+
+// Test a condition for the elements of the slice of the match context which are in the mask,
+// e.g. NewFullBitset(len(mctx.Values)) for every element.
+// The current index and captures of the match context are ignored.
+func TestSlice(cond Condition, mctx MatchContext, mask Bitset) Bitset {
+	return TestSliceOf(cond, mctx, mask)
+}
+
+// Typed variant of TestSlice.
+func TestSliceOf[T any](cond ConditionOf[T], mctx MatchContextOf[T], mask Bitset) Bitset {
+	mctx.Captures = nil
+
+	if sc, ok := cond.(SliceConditionOf[T]); ok {
+		return sc.TestSlice(mctx, mask)
+	}
+
+	return testEach(cond, mctx, mask)
+}
+
+// Test a condition for the elements in the mask, one at a time.
+func testEach[T any](cond ConditionOf[T], mctx MatchContextOf[T], mask Bitset) Bitset {
+	b := NewBitset(len(mctx.Values))
+	for i := mask.next(0); i >= 0; i = mask.next(i + 1) {
+		if cond.Test(mctx.at(i)) {
+			b.Set(i)
+		}
+	}
+
+	return b
 }
@@ -110,12 +110,16 @@ var (
 )
 
 func testCond(t *testing.T, c condition.Condition, test CondTest) {
-	for _, ex := range test.Expectations {
+	testCondOf(t, c, test.Values, test.Expectations)
+}
+
+func testCondOf[T any](t *testing.T, c condition.ConditionOf[T], values []T, expectations []CondTestExpectation) {
+	for _, ex := range expectations {
 		if ex.Success {
 			assert.True(
 				t,
-				c.Test(condition.MatchContext{
-					Values:       test.Values,
+				c.Test(condition.MatchContextOf[T]{
+					Values:       values,
 					CurrentIndex: ex.Index,
 				}),
 				"Index: %d",
@@ -124,8 +128,8 @@ func testCond(t *testing.T, c condition.Condition, test CondTest) {
 		} else {
 			assert.Falsef(
 				t,
-				c.Test(condition.MatchContext{
-					Values:       test.Values,
+				c.Test(condition.MatchContextOf[T]{
+					Values:       values,
 					CurrentIndex: ex.Index,
 				}),
 				"Index: %d",
@@ -1,14 +1,66 @@
 package condition
 
-type MatchContext struct {
-	// The array of values to be matched with.
-	Values []interface{}
+type (
+	MatchContextOf[T any] struct {
+		// The array of values to be matched with.
+		Values []T
 
-	// The current index of the value to be matched.
-	CurrentIndex int
-}
+		// The current index of the value to be matched.
+		CurrentIndex int
+
+		// The captures recorded by the conditions while matching.
+		// Nothing is recorded if it is nil.
+		Captures Captures
+
+		// The storage of the results computed by conditions once per slice.
+		// Such results are computed again for every element if it is nil.
+		Cache *Cache
+	}
+
+	MatchContext = MatchContextOf[interface{}]
+)
 
 // Obtain the current value derived from the current index.
-func (c MatchContext) CurrentValue() interface{} {
+func (c MatchContextOf[T]) CurrentValue() T {
 	return c.Values[c.CurrentIndex]
 }
+
+// Record a named capture for the current match.
+func (c MatchContextOf[T]) Capture(name string, value string) {
+	if c.Captures != nil {
+		c.Captures[name] = value
+	}
+}
+
+// Derive the match context for another element of the same slice, without the captures.
+func (c MatchContextOf[T]) at(index int) MatchContextOf[T] {
+	return MatchContextOf[T]{
+		Values:       c.Values,
+		CurrentIndex: index,
+		Cache:        c.Cache,
+	}
+}
+
+// Derive the match context with the given captures.
+func (c MatchContextOf[T]) withCaptures(captures Captures) MatchContextOf[T] {
+	c.Captures = captures
+	return c
+}
+
+// Test with the given function, only keeping the recorded captures if it matches.
+func testCapturing[T any](mctx MatchContextOf[T], fn func(mctx MatchContextOf[T]) bool) bool {
+	if mctx.Captures == nil {
+		return fn(mctx)
+	}
+
+	captures := make(Captures)
+	if !fn(mctx.withCaptures(captures)) {
+		return false
+	}
+
+	for name, value := range captures {
+		mctx.Captures[name] = value
+	}
+
+	return true
+}
@@ -5,7 +5,15 @@ import "errors"
 var (
 	ErrEmptyCond             = errors.New("empty condition")
 	ErrInvalidInterval       = errors.New("invalid interval")
+	ErrInvalidOffset         = errors.New("invalid offset")
 	ErrInvalidMaxDist        = errors.New("invalid max distance")
 	ErrInvalidStartDist      = errors.New("invalid start distance")
 	ErrInvalidMaxOrStartDist = errors.New("invalid max or start distance")
+	ErrInvalidMinCount       = errors.New("invalid min count")
+	ErrInvalidMaxCount       = errors.New("invalid max count")
+	ErrInvalidMinOrMaxCount  = errors.New("invalid min or max count")
+	ErrInvalidIndexRange     = errors.New("invalid index range")
+	ErrInvalidModulus        = errors.New("invalid modulus")
+	ErrInvalidGlob           = errors.New("invalid glob pattern")
+	ErrNotInterface          = errors.New("not an interface type")
 )
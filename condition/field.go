@@ -0,0 +1,304 @@
+package condition
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	fieldPath struct {
+		segments []pathSegment
+		fieldOptions
+	}
+
+	pathSegment struct {
+		name     string
+		index    int
+		isIndex  bool
+		wildcard bool
+
+		// Accessors of the segment resolved once per struct or map type.
+		accessors *sync.Map
+	}
+
+	// Resolved access of a segment into a struct or map type.
+	segmentAccessor struct {
+		// The field index chain for a struct type.
+		index []int
+
+		// The key for a map type.
+		key reflect.Value
+
+		ok bool
+	}
+
+	fieldOptions struct {
+		tag         string
+		wildcardAll bool
+	}
+
+	FieldOption func(o *fieldOptions)
+)
+
+// The wildcard segment of a target, matching every element of a slice, array, or map.
+const Wildcard = "*"
+
+// Resolve struct fields by the name in the given struct tag (e.g. "json") instead of the field name.
+// Fields without a name in the tag are still resolved by their field name,
+// while fields with "-" as their name are never resolved.
+func WithTag(tag string) FieldOption {
+	return func(o *fieldOptions) {
+		o.tag = tag
+	}
+}
+
+// All elements matched by a wildcard must satisfy the check.
+// By default, any element satisfying the check is enough.
+func WithWildcardAll(wildcardAll bool) FieldOption {
+	return func(o *fieldOptions) {
+		o.wildcardAll = wildcardAll
+	}
+}
+
+// Parse a dot-separated target.
+//
+// Each segment of the target is resolved depending on the value it is applied to:
+//   - struct: the field of the given name (or tag name with WithTag).
+//   - map: the value of the given key, converted to the key type of the map.
+//   - slice or array: the element at the given index, counting from the end if negative.
+//
+// A Wildcard segment resolves every element of a slice, array, or map.
+func parseFieldPath(target string, options []FieldOption) fieldPath {
+	names := strings.Split(target, ".")
+
+	p := fieldPath{
+		segments: make([]pathSegment, 0, len(names)),
+	}
+
+	for _, name := range names {
+		seg := pathSegment{
+			name:      name,
+			wildcard:  name == Wildcard,
+			accessors: &sync.Map{},
+		}
+
+		if index, err := strconv.Atoi(name); err == nil {
+			seg.index = index
+			seg.isIndex = true
+		}
+
+		p.segments = append(p.segments, seg)
+	}
+
+	for _, option := range options {
+		option(&p.fieldOptions)
+	}
+
+	return p
+}
+
+// Resolve the path on the current value and test the resolved values with the given function.
+// The current value itself is tested if the path is empty.
+func testField[T any](mctx MatchContextOf[T], p fieldPath, fn func(rv reflect.Value) bool) bool {
+	// Reflect through a pointer to avoid copying the element.
+	rv := reflect.ValueOf(&mctx.Values[mctx.CurrentIndex]).Elem()
+	return p.test(rv, p.segments, fn)
+}
+
+func (p fieldPath) test(rv reflect.Value, segments []pathSegment, fn func(rv reflect.Value) bool) bool {
+	if len(segments) == 0 {
+		return fn(rv)
+	}
+
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+
+	seg := segments[0]
+	if !seg.wildcard {
+		next, ok := p.step(rv, seg)
+		if !ok {
+			return false
+		}
+
+		return p.test(next, segments[1:], fn)
+	}
+
+	var (
+		n    int
+		elem func(i int) reflect.Value
+	)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		n, elem = rv.Len(), rv.Index
+	case reflect.Map:
+		iter := rv.MapRange()
+		n, elem = rv.Len(), func(i int) reflect.Value {
+			iter.Next()
+			return iter.Value()
+		}
+	default:
+		return false
+	}
+
+	if p.wildcardAll {
+		for i := 0; i < n; i++ {
+			if !p.test(elem(i), segments[1:], fn) {
+				return false
+			}
+		}
+
+		return n != 0
+	}
+
+	for i := 0; i < n; i++ {
+		if p.test(elem(i), segments[1:], fn) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p fieldPath) step(rv reflect.Value, seg pathSegment) (reflect.Value, bool) {
+	switch rv.Kind() {
+	case reflect.Struct:
+		acc := p.accessor(rv.Type(), seg)
+		if !acc.ok {
+			return reflect.Value{}, false
+		}
+
+		if len(acc.index) == 1 {
+			return rv.Field(acc.index[0]), true
+		}
+
+		// Promoted fields of embedded nil pointers cannot be resolved.
+		next, err := rv.FieldByIndexErr(acc.index)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		return next, true
+	case reflect.Map:
+		acc := p.accessor(rv.Type(), seg)
+		if !acc.ok {
+			return reflect.Value{}, false
+		}
+
+		next := rv.MapIndex(acc.key)
+		return next, next.IsValid()
+	case reflect.Slice, reflect.Array:
+		if !seg.isIndex {
+			return reflect.Value{}, false
+		}
+
+		index := seg.index
+		if index < 0 {
+			index += rv.Len()
+		}
+
+		if index < 0 || index >= rv.Len() {
+			return reflect.Value{}, false
+		}
+
+		return rv.Index(index), true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+// Obtain the accessor of a segment into a struct or map type, resolving it on first use.
+func (p fieldPath) accessor(typ reflect.Type, seg pathSegment) segmentAccessor {
+	if acc, ok := seg.accessors.Load(typ); ok {
+		return acc.(segmentAccessor)
+	}
+
+	var acc segmentAccessor
+	if typ.Kind() == reflect.Struct {
+		var sf reflect.StructField
+		sf, acc.ok = p.structField(typ, seg.name)
+		acc.index = sf.Index
+	} else {
+		acc.key, acc.ok = mapKey(typ.Key(), seg.name)
+	}
+
+	seg.accessors.Store(typ, acc)
+	return acc
+}
+
+// Find the exported field of a struct type by its name, or by its tag name if a tag is set.
+func (p fieldPath) structField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	if p.tag == "" {
+		sf, ok := typ.FieldByName(name)
+		return sf, ok && sf.IsExported()
+	}
+
+	for _, sf := range reflect.VisibleFields(typ) {
+		if !sf.IsExported() {
+			continue
+		}
+
+		tagName, _, _ := strings.Cut(sf.Tag.Get(p.tag), ",")
+		switch {
+		case tagName == "-":
+			continue
+		case tagName == "" && sf.Anonymous:
+			// Untagged embedded structs are only resolved through their promoted fields.
+			continue
+		case tagName == "":
+			tagName = sf.Name
+		}
+
+		if tagName == name {
+			return sf, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// Convert a segment to a key of the given map key type.
+func mapKey(typ reflect.Type, name string) (reflect.Value, bool) {
+	switch typ.Kind() {
+	case reflect.String, reflect.Interface:
+		rv := reflect.ValueOf(name)
+		if !rv.Type().ConvertibleTo(typ) {
+			return reflect.Value{}, false
+		}
+
+		return rv.Convert(typ), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(name, 10, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		return reflect.ValueOf(i).Convert(typ), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(name, 10, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		return reflect.ValueOf(u).Convert(typ), true
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(name, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		return reflect.ValueOf(f).Convert(typ), true
+	case reflect.Bool:
+		b, err := strconv.ParseBool(name)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		return reflect.ValueOf(b).Convert(typ), true
+	default:
+		return reflect.Value{}, false
+	}
+}
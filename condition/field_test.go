@@ -0,0 +1,253 @@
+package condition_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+)
+
+type exampleOrder struct {
+	exampleOrderMeta
+
+	OrderID string            `json:"order_id"`
+	Items   []exampleItem     `json:"items"`
+	Totals  [2]int            `json:"totals"`
+	Codes   map[int]string    `json:"codes"`
+	Flags   map[bool]string   `json:"flags,omitempty"`
+	Labels  map[string]string `json:"-"`
+	secret  string
+}
+
+type exampleOrderMeta struct {
+	Source string `json:"source"`
+}
+
+type exampleItem struct {
+	Name  string `json:"name"`
+	Price int    `json:"price"`
+}
+
+var dummyOrderValues = []exampleOrder{
+	{
+		exampleOrderMeta: exampleOrderMeta{Source: "web"},
+		OrderID:          "A-1",
+		Items: []exampleItem{
+			{Name: "apple", Price: 100},
+			{Name: "banana", Price: 50},
+		},
+		Totals: [2]int{150, 15},
+		Codes:  map[int]string{1: "new", 2: "paid"},
+		Flags:  map[bool]string{true: "priority"},
+		Labels: map[string]string{"team": "red"},
+		secret: "hidden",
+	},
+	{
+		exampleOrderMeta: exampleOrderMeta{Source: "store"},
+		OrderID:          "A-2",
+		Items: []exampleItem{
+			{Name: "banana", Price: 50},
+		},
+		Totals: [2]int{50, 5},
+		Codes:  map[int]string{1: "new"},
+	},
+	{
+		OrderID: "A-3",
+	},
+}
+
+func TestFieldCheckIndex(t *testing.T) {
+	c := condition.FieldCheck("Items.0.Name", condition.Eq("banana"))
+
+	test := CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{1}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCheckNegativeIndex(t *testing.T) {
+	c := condition.FieldCheck("Items.-1.Name", condition.Eq("banana"))
+
+	test := CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{0, 1}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCheckArrayIndex(t *testing.T) {
+	c := condition.FieldCheck("Totals.1", condition.Gt(10))
+
+	test := CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{0}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCheckWildcardAny(t *testing.T) {
+	c := condition.FieldCheck("Items.*.Name", condition.Eq("apple"))
+
+	test := CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{0}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCheckWildcardAll(t *testing.T) {
+	c := condition.FieldCheck("Items.*.Price", condition.Lte(50), condition.WithWildcardAll(true))
+
+	test := CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{1}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCheckWildcardMap(t *testing.T) {
+	c := condition.FieldCheck("Codes.*", condition.Eq("paid"))
+
+	test := CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{0}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCheckMapKey(t *testing.T) {
+	c := condition.Or(
+		condition.FieldCheck("Codes.2", condition.Eq("paid")),
+		condition.FieldCheck("Flags.true", condition.Eq("priority")),
+	)
+
+	test := CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{0}),
+	}
+
+	testCond(t, c, test)
+
+	c = condition.FieldCheck("Codes.new", condition.Eq("paid"))
+
+	test = CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCheckTag(t *testing.T) {
+	c := condition.And(
+		condition.FieldCheck("order_id", condition.HasPrefix("A-"), condition.WithTag("json")),
+		condition.FieldCheck("items.*.name", condition.Eq("banana"), condition.WithTag("json")),
+		condition.FieldCheck("source", condition.Eq("web"), condition.WithTag("json")),
+	)
+
+	test := CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{0}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCheckTagExcluded(t *testing.T) {
+	values := toInterfaces(dummyOrderValues)
+
+	testCond(t, condition.FieldCheck("Labels.team", condition.Eq("red"), condition.WithTag("json")), CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{}),
+	})
+
+	testCond(t, condition.FieldCheck("OrderID", condition.Eq("A-1"), condition.WithTag("json")), CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{}),
+	})
+
+	testCond(t, condition.FieldCheck("Labels.team", condition.Eq("red")), CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0}),
+	})
+}
+
+func TestFieldCheckUnexported(t *testing.T) {
+	c := condition.FieldCheck("secret", condition.Eq("hidden"))
+
+	test := CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCheckConcurrent(t *testing.T) {
+	c := condition.FieldCheck("items.*.name", condition.Eq("banana"), condition.WithTag("json"))
+	values := []interface{}{
+		dummyOrderValues[0],
+		&dummyOrderValues[1],
+		dummyOrderValues[2],
+		dummyStructValues[0],
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			testCond(t, c, CondTest{
+				Values:       values,
+				Expectations: makeExpectations(len(values), []int{0, 1}),
+			})
+		}()
+	}
+
+	wg.Wait()
+}
+
+func toInterfaces[T any](values []T) []interface{} {
+	xs := make([]interface{}, 0, len(values))
+	for _, x := range values {
+		xs = append(xs, x)
+	}
+
+	return xs
+}
+
+func benchmarkFieldCheck(b *testing.B, c condition.Condition) {
+	values := toInterfaces(dummyOrderValues)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c.Test(condition.MatchContext{
+			Values:       values,
+			CurrentIndex: i % len(values),
+		})
+	}
+}
+
+func BenchmarkFieldCheck(b *testing.B) {
+	benchmarkFieldCheck(b, condition.FieldCheck("Items.0.Name", condition.Eq("banana")))
+}
+
+func BenchmarkFieldCheckPromoted(b *testing.B) {
+	benchmarkFieldCheck(b, condition.FieldCheck("Source", condition.Eq("web")))
+}
+
+func BenchmarkFieldCheckTag(b *testing.B) {
+	benchmarkFieldCheck(b, condition.FieldCheck("items.*.name", condition.Eq("banana"), condition.WithTag("json")))
+}
+
+func BenchmarkFieldCheckMapKey(b *testing.B) {
+	benchmarkFieldCheck(b, condition.FieldCheck("Codes.2", condition.Eq("paid")))
+}
@@ -1,51 +1,125 @@
 package condition
 
+import "sync"
+
 type (
-	lookaroundCond struct {
-		fn        LookaroundCondFunc
+	lookaroundCond[T any] struct {
+		fn LookaroundCondFuncOf[T]
+
+		// The condition returned by fn for every element, if known.
+		cond ConditionOf[T]
+
+		lookaroundOptions
+	}
+
+	// The results of the condition of a lookaround created from LookaroundP, stored once per slice.
+	lookaroundState struct {
+		mu      sync.Mutex
+		results []uint32
+
+		// Links from the elements known to fail or to satisfy the condition to the next element to look at,
+		// so that a scan skips them at once.
+		failed    []int
+		satisfied []int
+	}
+
+	lookaroundOptions struct {
 		interval  int
 		maxDist   int
 		startDist int
 		all       bool
+		minCount  int
+		maxCount  int
+		hasMin    bool
+		hasMax    bool
 	}
 
-	LookaroundOption   func(c *lookaroundCond)
-	LookaroundCondFunc func(x interface{}) Condition
+	LookaroundOption            func(o *lookaroundOptions)
+	LookaroundCondFuncOf[T any] func(x T) ConditionOf[T]
+	LookaroundCondFunc          = LookaroundCondFuncOf[interface{}]
 )
 
 // Matches to true if any elements before it satisfies the given condition.
 func LookBeforeAny(cond Condition) Condition {
-	return Lookaround(P(cond), -1)
+	return LookBeforeAnyOf(cond)
+}
+
+// Typed variant of LookBeforeAny.
+func LookBeforeAnyOf[T any](cond ConditionOf[T]) ConditionOf[T] {
+	return LookaroundPOf(cond, -1)
 }
 
 // Matches to true if all elements before it satisfies the given condition.
 func LookBeforeAll(cond Condition) Condition {
-	return Lookaround(P(cond), -1, WithAll(true))
+	return LookBeforeAllOf(cond)
+}
+
+// Typed variant of LookBeforeAll.
+func LookBeforeAllOf[T any](cond ConditionOf[T]) ConditionOf[T] {
+	return LookaroundPOf(cond, -1, WithAll(true))
 }
 
 // Matches to true if any element after it satisfies the given condition.
 func LookAfterAny(cond Condition) Condition {
-	return Lookaround(P(cond), 1)
+	return LookAfterAnyOf(cond)
+}
+
+// Typed variant of LookAfterAny.
+func LookAfterAnyOf[T any](cond ConditionOf[T]) ConditionOf[T] {
+	return LookaroundPOf(cond, 1)
 }
 
 // Matches to true if all element after it satisfies the given condition.
 func LookAfterAll(cond Condition) Condition {
-	return Lookaround(P(cond), 1, WithAll(true))
+	return LookAfterAllOf(cond)
+}
+
+// Typed variant of LookAfterAll.
+func LookAfterAllOf[T any](cond ConditionOf[T]) ConditionOf[T] {
+	return LookaroundPOf(cond, 1, WithAll(true))
 }
 
 // Matches to true if elements around the current element is satisfies the given condition.
 func Lookaround(fn LookaroundCondFunc, interval int, options ...LookaroundOption) Condition {
+	return LookaroundOf(fn, interval, options...)
+}
+
+// Typed variant of Lookaround.
+func LookaroundOf[T any](fn LookaroundCondFuncOf[T], interval int, options ...LookaroundOption) ConditionOf[T] {
+	return newLookaroundCond(fn, nil, interval, options)
+}
+
+// Matches to true if elements around the current element is satisfies the given condition,
+// which does not depend on the current element.
+//
+// It matches like Lookaround(P(cond), ...), but if the match context has a cache, the result of the condition
+// is stored once per element of the slice, and a scan skips the elements whose result cannot change its outcome.
+// The condition is only tested on the elements a scan would test, and at most once per element.
+// Lookarounds matching any or all elements then take linear time over a slice.
+func LookaroundP(cond Condition, interval int, options ...LookaroundOption) Condition {
+	return LookaroundPOf(cond, interval, options...)
+}
+
+// Typed variant of LookaroundP.
+func LookaroundPOf[T any](cond ConditionOf[T], interval int, options ...LookaroundOption) ConditionOf[T] {
+	return newLookaroundCond(POf(cond), cond, interval, options)
+}
+
+func newLookaroundCond[T any](fn LookaroundCondFuncOf[T], cond ConditionOf[T], interval int, options []LookaroundOption) *lookaroundCond[T] {
 	if interval == 0 {
 		panic(ErrInvalidInterval)
 	}
 
-	c := lookaroundCond{
-		fn:       fn,
-		interval: interval,
+	c := &lookaroundCond[T]{
+		fn:   fn,
+		cond: cond,
+		lookaroundOptions: lookaroundOptions{
+			interval: interval,
+		},
 	}
 
 	for _, option := range options {
-		option(&c)
+		option(&c.lookaroundOptions)
 	}
 
 	return c
@@ -53,58 +127,125 @@ func Lookaround(fn LookaroundCondFunc, interval int, options ...LookaroundOption
 
 // Condition function for lookaround at the current element.
 func P(cond Condition) LookaroundCondFunc {
-	return func(x interface{}) Condition {
+	return POf(cond)
+}
+
+// Typed variant of P.
+func POf[T any](cond ConditionOf[T]) LookaroundCondFuncOf[T] {
+	return func(x T) ConditionOf[T] {
 		return cond
 	}
 }
 
 // The maximum distance from the current element.
 func WithMaxDist(maxDist int) LookaroundOption {
-	return func(c *lookaroundCond) {
+	return func(o *lookaroundOptions) {
 		if maxDist < 0 {
 			panic(ErrInvalidMaxDist)
 		}
 
-		if maxDist != 0 && c.startDist != 0 && maxDist < c.startDist {
+		if maxDist != 0 && o.startDist != 0 && maxDist < o.startDist {
 			panic(ErrInvalidMaxOrStartDist)
 		}
 
-		c.maxDist = maxDist
+		o.maxDist = maxDist
 	}
 }
 
 // The distance to start searching around the current element.
 func WithStartDist(startDist int) LookaroundOption {
-	return func(c *lookaroundCond) {
+	return func(o *lookaroundOptions) {
 		if startDist < 0 {
 			panic(ErrInvalidStartDist)
 		}
 
-		if startDist != 0 && c.maxDist != 0 && startDist > c.maxDist {
+		if startDist != 0 && o.maxDist != 0 && startDist > o.maxDist {
 			panic(ErrInvalidMaxOrStartDist)
 		}
 
-		c.startDist = startDist
+		o.startDist = startDist
 	}
 }
 
 // All elements around the current element must satisfy the condition.
+// It takes precedence over the count options.
 func WithAll(all bool) LookaroundOption {
-	return func(c *lookaroundCond) {
-		c.all = all
+	return func(o *lookaroundOptions) {
+		o.all = all
+	}
+}
+
+// At least the given number of elements around the current element must satisfy the condition.
+func WithAtLeast(minCount int) LookaroundOption {
+	return func(o *lookaroundOptions) {
+		if minCount < 0 {
+			panic(ErrInvalidMinCount)
+		}
+
+		if o.hasMax && minCount > o.maxCount {
+			panic(ErrInvalidMinOrMaxCount)
+		}
+
+		o.minCount = minCount
+		o.hasMin = true
+	}
+}
+
+// At most the given number of elements around the current element may satisfy the condition.
+// Unless WithAtLeast is also used, no elements satisfying the condition is allowed as well.
+func WithAtMost(maxCount int) LookaroundOption {
+	return func(o *lookaroundOptions) {
+		if maxCount < 0 {
+			panic(ErrInvalidMaxCount)
+		}
+
+		if o.hasMin && maxCount < o.minCount {
+			panic(ErrInvalidMinOrMaxCount)
+		}
+
+		o.maxCount = maxCount
+		o.hasMax = true
+	}
+}
+
+// Exactly the given number of elements around the current element must satisfy the condition.
+func WithExactly(count int) LookaroundOption {
+	return func(o *lookaroundOptions) {
+		if count < 0 {
+			panic(ErrInvalidMinCount)
+		}
+
+		o.minCount, o.maxCount = count, count
+		o.hasMin, o.hasMax = true, true
+	}
+}
+
+// Obtain the range of elements satisfying the condition required for a match.
+// The maximum is negative if it is unbounded.
+func (o lookaroundOptions) countRange() (int, int) {
+	minCount, maxCount := 1, -1
+	if o.hasMax {
+		minCount, maxCount = 0, o.maxCount
 	}
+
+	if o.hasMin {
+		minCount = o.minCount
+	}
+
+	return minCount, maxCount
 }
 
-func (c lookaroundCond) Test(mctx MatchContext) bool {
+// Obtain the first index to look at and the bounds of the indices to look at around the given index.
+func (o lookaroundOptions) bounds(index int, n int) (int, int, int) {
 	low := 0
-	cLow := mctx.CurrentIndex - c.maxDist
-	if c.maxDist != 0 && cLow >= 0 {
+	cLow := index - o.maxDist
+	if o.maxDist != 0 && cLow >= 0 {
 		low = cLow
 	}
 
-	high := len(mctx.Values) - 1
-	cHigh := mctx.CurrentIndex + c.maxDist
-	if c.maxDist != 0 && cHigh < len(mctx.Values) {
+	high := n - 1
+	cHigh := index + o.maxDist
+	if o.maxDist != 0 && cHigh < n {
 		high = cHigh
 	}
 
@@ -112,32 +253,55 @@ func (c lookaroundCond) Test(mctx MatchContext) bool {
 	// - <current index> + 1 if interval is positive
 	// - <current index> - 1 if interval is negative
 	var start int
-	if c.interval < 0 {
-		if c.startDist != 0 {
-			start = mctx.CurrentIndex - c.startDist
+	if o.interval < 0 {
+		if o.startDist != 0 {
+			start = index - o.startDist
 		} else {
-			start = mctx.CurrentIndex - 1
+			start = index - 1
 		}
-	} else if c.interval > 0 {
-		if c.startDist != 0 {
-			start = mctx.CurrentIndex + c.startDist
+	} else if o.interval > 0 {
+		if o.startDist != 0 {
+			start = index + o.startDist
 		} else {
-			start = mctx.CurrentIndex + 1
+			start = index + 1
 		}
 	}
 
+	return start, low, high
+}
+
+// Obtain the number of indices left to look at from the given index, inclusive.
+func (o lookaroundOptions) remaining(j int, low int, high int) int {
+	if j < low || j > high {
+		return 0
+	}
+
+	if o.interval < 0 {
+		return (j-low)/-o.interval + 1
+	}
+
+	return (high-j)/o.interval + 1
+}
+
+func (c *lookaroundCond[T]) Test(mctx MatchContextOf[T]) bool {
+	start, low, high := c.bounds(mctx.CurrentIndex, len(mctx.Values))
+
+	if c.cond != nil && mctx.Cache != nil {
+		state := c.load(mctx)
+
+		state.mu.Lock()
+		defer state.mu.Unlock()
+
+		return c.scan(mctx, state, start, low, high)
+	}
+
 	cond := c.fn(mctx.CurrentValue())
 
 	if c.all {
 		matched := false
 
 		for j := start; j >= low && j <= high; j += c.interval {
-			submctx := MatchContext{
-				Values:       mctx.Values,
-				CurrentIndex: j,
-			}
-
-			if !cond.Test(submctx) {
+			if !cond.Test(mctx.at(j)) {
 				return false
 			}
 
@@ -147,16 +311,167 @@ func (c lookaroundCond) Test(mctx MatchContext) bool {
 		return matched
 	}
 
+	minCount, maxCount := c.countRange()
+	if minCount == 0 && maxCount < 0 {
+		return true
+	}
+
+	count := 0
 	for j := start; j >= low && j <= high; j += c.interval {
-		submctx := MatchContext{
-			Values:       mctx.Values,
-			CurrentIndex: j,
+		remaining := c.remaining(j, low, high)
+
+		// Stop once the elements left are not enough to satisfy the minimum.
+		if count+remaining < minCount {
+			return false
 		}
 
-		if cond.Test(submctx) {
+		// Stop once the minimum is satisfied and the elements left are not enough to exceed the maximum.
+		if count >= minCount && (maxCount < 0 || count+remaining <= maxCount) {
 			return true
 		}
+
+		if !cond.Test(mctx.at(j)) {
+			continue
+		}
+
+		count++
+
+		if maxCount >= 0 && count > maxCount {
+			return false
+		}
+	}
+
+	return count >= minCount
+}
+
+func (c *lookaroundCond[T]) TestSlice(mctx MatchContextOf[T], mask Bitset) Bitset {
+	if c.cond == nil {
+		return testEach[T](c, mctx, mask)
+	}
+
+	// Without a cache, the results are still shared by the elements of this call.
+	var state *lookaroundState
+	if mctx.Cache != nil {
+		state = c.load(mctx)
+	} else {
+		state = newLookaroundState(len(mctx.Values))
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	b := NewBitset(len(mctx.Values))
+	for i := mask.next(0); i >= 0; i = mask.next(i + 1) {
+		start, low, high := c.bounds(i, len(mctx.Values))
+		if c.scan(mctx, state, start, low, high) {
+			b.Set(i)
+		}
+	}
+
+	return b
+}
+
+// Load the results of the condition from the cache.
+func (c *lookaroundCond[T]) load(mctx MatchContextOf[T]) *lookaroundState {
+	return mctx.Cache.Load(c, func() interface{} {
+		return newLookaroundState(len(mctx.Values))
+	}).(*lookaroundState)
+}
+
+func newLookaroundState(n int) *lookaroundState {
+	state := &lookaroundState{
+		results:   make([]uint32, n),
+		failed:    make([]int, n),
+		satisfied: make([]int, n),
+	}
+
+	for j := 0; j < n; j++ {
+		state.failed[j], state.satisfied[j] = j, j
 	}
 
+	return state
+}
+
+// Scan like Test does, but skipping the elements whose result cannot change the outcome of the scan.
+// Only the elements the scan would test are tested, each at most once per state.
+func (c *lookaroundCond[T]) scan(mctx MatchContextOf[T], state *lookaroundState, start int, low int, high int) bool {
+	if c.all {
+		// Every element satisfying the condition is passed, stopping at the first one failing.
+		j := skip(state.satisfied, start)
+		for ; j >= low && j <= high; j = skip(state.satisfied, j+c.interval) {
+			if !c.test(mctx, state, j) {
+				return false
+			}
+		}
+
+		return c.remaining(start, low, high) > 0
+	}
+
+	minCount, maxCount := c.countRange()
+	if minCount == 0 && maxCount < 0 {
+		return true
+	}
+
+	// The elements failing the condition leave the count as is, and the elements left only decrease over them,
+	// so the checks done on them by Test give the same outcome once done on the next element looked at.
+	count := 0
+	j := skip(state.failed, start)
+	for ; j >= low && j <= high; j = skip(state.failed, j+c.interval) {
+		remaining := c.remaining(j, low, high)
+
+		if count+remaining < minCount {
+			return false
+		}
+
+		if count >= minCount && (maxCount < 0 || count+remaining <= maxCount) {
+			return true
+		}
+
+		if !c.test(mctx, state, j) {
+			continue
+		}
+
+		count++
+
+		if maxCount >= 0 && count > maxCount {
+			return false
+		}
+	}
+
+	return count >= minCount
+}
+
+// Test the condition on the element at the given index, unless its result is stored already.
+func (c *lookaroundCond[T]) test(mctx MatchContextOf[T], state *lookaroundState, j int) bool {
+	if r := state.results[j]; r != memoUnknown {
+		return r == memoTrue
+	}
+
+	if c.cond.Test(mctx.at(j)) {
+		state.results[j] = memoTrue
+		state.satisfied[j] = j + c.interval
+		return true
+	}
+
+	state.results[j] = memoFalse
+	state.failed[j] = j + c.interval
 	return false
 }
+
+// Follow the given links from the given index up to the first element not linked,
+// which may be outside of the slice.
+func skip(links []int, j int) int {
+	last := j
+	for last >= 0 && last < len(links) && links[last] != last {
+		last = links[last]
+	}
+
+	// Shorten the followed links for the next scans.
+	for j != last {
+		next := links[j]
+		links[j] = last
+		j = next
+	}
+
+	return last
+}
@@ -400,3 +400,407 @@ func TestLookaroundPositiveIntervalWithMaxDistStartDistAndAll(t *testing.T) {
 
 	testCond(t, c, test)
 }
+
+func TestLookBeforeAnyOf(t *testing.T) {
+	c := condition.LookBeforeAnyOf(
+		condition.CheckOf(func(x int) bool {
+			return x == dummyIntValues[4]
+		}),
+	)
+
+	testCondOf(t, c, dummyIntValues, makeExpectations(len(dummyIntValues), []int{5, 6, 7, 8, 9}))
+}
+
+func TestLookaroundOfPositiveIntervalWithMaxDist(t *testing.T) {
+	intValues := []int{
+		70,
+		300,
+		300,
+		70,
+		300,
+		70,
+		70,
+		300,
+		1000,
+		50,
+	}
+
+	c := condition.AndOf(
+		condition.CheckOf(func(x int) bool {
+			return x == 70
+		}),
+		condition.LookaroundOf(
+			condition.POf(
+				condition.CheckOf(func(x int) bool {
+					return x < 200
+				}),
+			),
+			1,
+			condition.WithMaxDist(2),
+		),
+	)
+
+	testCondOf(t, c, intValues, makeExpectations(len(intValues), []int{3, 5}))
+}
+
+func TestLookaroundWithAtLeast(t *testing.T) {
+	intValues := []int{
+		0,
+		1,
+		0,
+		1,
+		1,
+		0,
+		1,
+		0,
+		0,
+	}
+
+	c := condition.Lookaround(
+		condition.P(condition.Check(condition.Eq(1))),
+		-1,
+		condition.WithMaxDist(4),
+		condition.WithAtLeast(3),
+	)
+
+	test := CondTest{
+		Values:       make([]interface{}, 0),
+		Expectations: makeExpectations(len(intValues), []int{5, 7}),
+	}
+	for _, x := range intValues {
+		test.Values = append(test.Values, x)
+	}
+
+	testCond(t, c, test)
+}
+
+func TestLookaroundWithAtMost(t *testing.T) {
+	intValues := []int{
+		1,
+		0,
+		1,
+		1,
+		0,
+		1,
+	}
+
+	c := condition.Lookaround(
+		condition.P(condition.Check(condition.Eq(1))),
+		1,
+		condition.WithAtMost(1),
+	)
+
+	test := CondTest{
+		Values:       make([]interface{}, 0),
+		Expectations: makeExpectations(len(intValues), []int{3, 4, 5}),
+	}
+	for _, x := range intValues {
+		test.Values = append(test.Values, x)
+	}
+
+	testCond(t, c, test)
+}
+
+func TestLookaroundWithExactlyAndInterval(t *testing.T) {
+	intValues := []int{
+		0,
+		1,
+		1,
+		0,
+		1,
+		1,
+		0,
+		1,
+	}
+
+	c := condition.Lookaround(
+		condition.P(condition.Check(condition.Eq(1))),
+		2,
+		condition.WithStartDist(1),
+		condition.WithExactly(2),
+	)
+
+	test := CondTest{
+		Values:       make([]interface{}, 0),
+		Expectations: makeExpectations(len(intValues), []int{1, 2, 4}),
+	}
+	for _, x := range intValues {
+		test.Values = append(test.Values, x)
+	}
+
+	testCond(t, c, test)
+}
+
+func TestLookaroundWithAtLeastShortCircuit(t *testing.T) {
+	values := []interface{}{1, 1, 1, 1, 1, 1, 1, 1}
+
+	calls := 0
+	counting := condition.Check(func(x interface{}) bool {
+		calls++
+		return x == 1
+	})
+
+	c := condition.Lookaround(condition.P(counting), 1, condition.WithAtLeast(2))
+	assert.True(t, c.Test(condition.MatchContext{Values: values, CurrentIndex: 0}))
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	c = condition.Lookaround(condition.P(counting), 1, condition.WithAtMost(2))
+	assert.False(t, c.Test(condition.MatchContext{Values: values, CurrentIndex: 0}))
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	c = condition.Lookaround(condition.P(counting), 1, condition.WithAtLeast(5), condition.WithMaxDist(4))
+	assert.False(t, c.Test(condition.MatchContext{Values: values, CurrentIndex: 0}))
+	assert.Equal(t, 0, calls)
+}
+
+func TestLookaroundWithAtLeastAndAtMostShortCircuit(t *testing.T) {
+	values := make([]interface{}, 50)
+	for i := range values {
+		values[i] = 1
+	}
+
+	calls := 0
+	counting := condition.Check(func(x interface{}) bool {
+		calls++
+		return x == 1
+	})
+
+	// The maximum cannot be exceeded by the 49 elements after the first, so the first match is enough.
+	c := condition.Lookaround(condition.P(counting), 1, condition.WithAtLeast(1), condition.WithAtMost(100))
+	assert.True(t, c.Test(condition.MatchContext{Values: values, CurrentIndex: 0}))
+	assert.Equal(t, 1, calls)
+
+	// No element needs to be tested if none is required and the maximum cannot be exceeded.
+	calls = 0
+	c = condition.Lookaround(condition.P(counting), 1, condition.WithAtMost(49))
+	assert.True(t, c.Test(condition.MatchContext{Values: values, CurrentIndex: 0}))
+	assert.Equal(t, 0, calls)
+
+	// The maximum is known not to be exceeded once the elements left are few enough.
+	calls = 0
+	c = condition.Lookaround(condition.P(counting), 1, condition.WithExactly(47))
+	assert.False(t, c.Test(condition.MatchContext{Values: values, CurrentIndex: 0}))
+	assert.Equal(t, 48, calls)
+}
+
+func TestLookaroundPanicInvalidCount(t *testing.T) {
+	assert.PanicsWithError(
+		t,
+		condition.ErrInvalidMinCount.Error(),
+		func() {
+			condition.Lookaround(
+				condition.P(condition.Check(condition.Eq(0))),
+				1,
+				condition.WithAtLeast(-1),
+			)
+		},
+	)
+
+	assert.PanicsWithError(
+		t,
+		condition.ErrInvalidMaxCount.Error(),
+		func() {
+			condition.Lookaround(
+				condition.P(condition.Check(condition.Eq(0))),
+				1,
+				condition.WithAtMost(-1),
+			)
+		},
+	)
+
+	assert.PanicsWithError(
+		t,
+		condition.ErrInvalidMinOrMaxCount.Error(),
+		func() {
+			condition.Lookaround(
+				condition.P(condition.Check(condition.Eq(0))),
+				1,
+				condition.WithAtLeast(3),
+				condition.WithAtMost(2),
+			)
+		},
+	)
+
+	assert.PanicsWithError(
+		t,
+		condition.ErrInvalidMinOrMaxCount.Error(),
+		func() {
+			condition.Lookaround(
+				condition.P(condition.Check(condition.Eq(0))),
+				1,
+				condition.WithAtMost(2),
+				condition.WithAtLeast(3),
+			)
+		},
+	)
+}
+
+func TestLookaroundPCacheEquivalent(t *testing.T) {
+	values := toInterfaces(dummyIntValues)
+	cond := condition.Check(func(x interface{}) bool {
+		return x.(int) > 200
+	})
+
+	optionSets := [][]condition.LookaroundOption{
+		{},
+		{condition.WithAll(true)},
+		{condition.WithAtLeast(2)},
+		{condition.WithAtMost(1)},
+		{condition.WithExactly(0)},
+		{condition.WithAtLeast(1), condition.WithAtMost(2)},
+	}
+
+	for _, interval := range []int{-3, -2, -1, 1, 2, 3} {
+		for maxDist := 0; maxDist <= 4; maxDist++ {
+			for startDist := 0; startDist <= 4; startDist++ {
+				if maxDist != 0 && startDist > maxDist {
+					continue
+				}
+
+				for k, optionSet := range optionSets {
+					options := append([]condition.LookaroundOption{
+						condition.WithMaxDist(maxDist),
+						condition.WithStartDist(startDist),
+					}, optionSet...)
+
+					expected := condition.Lookaround(condition.P(cond), interval, options...)
+					c := condition.LookaroundP(cond, interval, options...)
+
+					cache := condition.NewCache()
+					for i := range values {
+						assert.Equal(
+							t,
+							expected.Test(condition.MatchContext{Values: values, CurrentIndex: i}),
+							c.Test(condition.MatchContext{Values: values, CurrentIndex: i, Cache: cache}),
+							"Interval: %d, MaxDist: %d, StartDist: %d, Options: %d, Index: %d",
+							interval, maxDist, startDist, k, i,
+						)
+					}
+
+					mask := condition.NewFullBitset(len(values))
+					assert.Equal(
+						t,
+						condition.TestSlice(expected, condition.MatchContext{Values: values}, mask),
+						condition.TestSlice(c, condition.MatchContext{Values: values}, mask),
+						"Interval: %d, MaxDist: %d, StartDist: %d, Options: %d",
+						interval, maxDist, startDist, k,
+					)
+				}
+			}
+		}
+	}
+}
+
+func TestLookaroundPTestsScannedElements(t *testing.T) {
+	// Keep the values distinct to find the tested elements from their values.
+	values := make([]interface{}, 0, len(dummyIntValues))
+	for i, x := range dummyIntValues {
+		values = append(values, x*100+i)
+	}
+
+	optionSets := [][]condition.LookaroundOption{
+		{},
+		{condition.WithAll(true)},
+		{condition.WithAtLeast(2)},
+		{condition.WithAtMost(1)},
+		{condition.WithAtLeast(1), condition.WithAtMost(2)},
+		{condition.WithMaxDist(3), condition.WithStartDist(2)},
+		{condition.WithMaxDist(3), condition.WithAll(true)},
+	}
+
+	for _, interval := range []int{-2, -1, 1, 2} {
+		for k, optionSet := range optionSets {
+			scanned := make(map[int]bool)
+			expected := condition.Lookaround(condition.P(condition.Check(func(x interface{}) bool {
+				scanned[indexOf(values, x)] = true
+				return x.(int)/100 > 200
+			})), interval, optionSet...)
+
+			tested := make(map[int]int)
+			c := condition.LookaroundP(condition.Check(func(x interface{}) bool {
+				tested[indexOf(values, x)]++
+				return x.(int)/100 > 200
+			}), interval, optionSet...)
+
+			cache := condition.NewCache()
+			for i := range values {
+				expected.Test(condition.MatchContext{Values: values, CurrentIndex: i})
+				c.Test(condition.MatchContext{Values: values, CurrentIndex: i, Cache: cache})
+			}
+
+			for j, n := range tested {
+				assert.Equal(t, 1, n, "Interval: %d, Options: %d, Index: %d", interval, k, j)
+				assert.True(t, scanned[j], "Interval: %d, Options: %d, Index: %d", interval, k, j)
+			}
+		}
+	}
+}
+
+func indexOf(values []interface{}, x interface{}) int {
+	for i, v := range values {
+		if v == x {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func benchmarkLookaround(b *testing.B, c condition.Condition, cached bool) {
+	values := make([]interface{}, 2000)
+	for i := range values {
+		values[i] = i % 1000
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var cache *condition.Cache
+		if cached {
+			cache = condition.NewCache()
+		}
+
+		// Match every element of the slice, like a single MapSlice call.
+		for j := range values {
+			c.Test(condition.MatchContext{
+				Values:       values,
+				CurrentIndex: j,
+				Cache:        cache,
+			})
+		}
+	}
+}
+
+func BenchmarkLookBeforeAny(b *testing.B) {
+	benchmarkLookaround(b, condition.LookBeforeAny(condition.Check(condition.Eq(-1))), false)
+}
+
+func BenchmarkLookBeforeAnyCached(b *testing.B) {
+	benchmarkLookaround(b, condition.LookBeforeAny(condition.Check(condition.Eq(-1))), true)
+}
+
+func BenchmarkLookAfterAll(b *testing.B) {
+	benchmarkLookaround(b, condition.LookAfterAll(condition.Check(condition.Gte(0))), false)
+}
+
+func BenchmarkLookAfterAllCached(b *testing.B) {
+	benchmarkLookaround(b, condition.LookAfterAll(condition.Check(condition.Gte(0))), true)
+}
+
+func TestLookBeforeAnyMixedValues(t *testing.T) {
+	values := []interface{}{5, exampleStruct{Field3: "header"}, exampleStruct{Field3: "detail"}, exampleStruct{Field3: "detail"}}
+
+	isDetail := condition.FieldCheck("Field3", condition.Eq("detail"))
+	isHeader := condition.Check(func(x interface{}) bool {
+		return x.(exampleStruct).Field3 == "header"
+	})
+
+	// The scan stops at the header, never asserting the type of the first element.
+	c := condition.And(isDetail, condition.LookBeforeAny(isHeader))
+
+	cache := condition.NewCache()
+	for i, expected := range []bool{false, false, true, true} {
+		assert.Equal(t, expected, c.Test(condition.MatchContext{Values: values, CurrentIndex: i, Cache: cache}), "Index: %d", i)
+	}
+}
@@ -0,0 +1,103 @@
+package condition
+
+import "sync/atomic"
+
+type memoCond[T any] struct {
+	cond ConditionOf[T]
+}
+
+const (
+	memoUnknown uint32 = iota
+	memoFalse
+	memoTrue
+)
+
+// Memoize the given condition, so that it is tested at most once per element of the slice
+// if the match context has a cache, such as when used by conma.Map.
+//
+// Use the returned condition wherever the condition is shared, e.g. between entries or inside lookarounds.
+// Only the conditions wrapped by Memo are memoized, so impure conditions are left alone unless opted in.
+// Once memoized, the first result of an element is kept even if the condition would change its mind.
+//
+// The condition is tested every time if the match context records captures, to record them again.
+func Memo(cond Condition) Condition {
+	return MemoOf(cond)
+}
+
+// Typed variant of Memo.
+func MemoOf[T any](cond ConditionOf[T]) ConditionOf[T] {
+	if cond == nil {
+		panic(ErrEmptyCond)
+	}
+
+	return &memoCond[T]{
+		cond: cond,
+	}
+}
+
+func (c *memoCond[T]) Test(mctx MatchContextOf[T]) bool {
+	if mctx.Cache == nil || mctx.Captures != nil {
+		return c.cond.Test(mctx)
+	}
+
+	result := &c.results(mctx)[mctx.CurrentIndex]
+	if r := atomic.LoadUint32(result); r != memoUnknown {
+		return r == memoTrue
+	}
+
+	r := memoFalse
+	if c.cond.Test(mctx) {
+		r = memoTrue
+	}
+
+	// Keep the result stored first if the element is tested concurrently.
+	if !atomic.CompareAndSwapUint32(result, memoUnknown, r) {
+		r = atomic.LoadUint32(result)
+	}
+
+	return r == memoTrue
+}
+
+func (c *memoCond[T]) TestSlice(mctx MatchContextOf[T], mask Bitset) Bitset {
+	if mctx.Cache == nil {
+		return TestSliceOf(c.cond, mctx, mask)
+	}
+
+	results := c.results(mctx)
+
+	// Only the elements without a result yet are tested, all at once.
+	unknown := NewBitset(len(results))
+	for i := mask.next(0); i >= 0; i = mask.next(i + 1) {
+		if atomic.LoadUint32(&results[i]) == memoUnknown {
+			unknown.Set(i)
+		}
+	}
+
+	if !unknown.none() {
+		satisfied := TestSliceOf(c.cond, mctx, unknown)
+		for i := unknown.next(0); i >= 0; i = unknown.next(i + 1) {
+			r := memoFalse
+			if satisfied.Test(i) {
+				r = memoTrue
+			}
+
+			atomic.CompareAndSwapUint32(&results[i], memoUnknown, r)
+		}
+	}
+
+	b := NewBitset(len(results))
+	for i := mask.next(0); i >= 0; i = mask.next(i + 1) {
+		if atomic.LoadUint32(&results[i]) == memoTrue {
+			b.Set(i)
+		}
+	}
+
+	return b
+}
+
+// Load the results of the elements from the cache.
+func (c *memoCond[T]) results(mctx MatchContextOf[T]) []uint32 {
+	return mctx.Cache.Load(c, func() interface{} {
+		return make([]uint32, len(mctx.Values))
+	}).([]uint32)
+}
@@ -0,0 +1,130 @@
+package condition_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoPanicEmptyCond(t *testing.T) {
+	assert.PanicsWithError(
+		t,
+		condition.ErrEmptyCond.Error(),
+		func() {
+			condition.Memo(nil)
+		},
+	)
+}
+
+func TestMemo(t *testing.T) {
+	intValues := dummyIntValues
+
+	c := condition.Memo(condition.Check(condition.Gt(300)))
+
+	test := CondTest{
+		Values:       toInterfaces(intValues),
+		Expectations: makeExpectations(len(intValues), []int{0, 1, 3, 8}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestMemoLookaround(t *testing.T) {
+	values := toInterfaces(dummyIntValues)
+
+	calls := 0
+	memo := condition.Memo(condition.Check(func(x interface{}) bool {
+		calls++
+		return x.(int) > 300
+	}))
+
+	c := condition.Or(
+		memo,
+		condition.Lookaround(condition.P(memo), -1, condition.WithMaxDist(2)),
+		condition.Lookaround(condition.P(memo), 1, condition.WithMaxDist(2)),
+	)
+
+	cache := condition.NewCache()
+	for i := range values {
+		c.Test(condition.MatchContext{
+			Values:       values,
+			CurrentIndex: i,
+			Cache:        cache,
+		})
+	}
+
+	assert.Equal(t, len(values), calls)
+}
+
+func TestMemoWithoutCache(t *testing.T) {
+	values := toInterfaces(dummyIntValues)
+
+	calls := 0
+	c := condition.Memo(condition.Check(func(x interface{}) bool {
+		calls++
+		return true
+	}))
+
+	for i := 0; i < 2; i++ {
+		c.Test(condition.MatchContext{Values: values})
+	}
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestMemoImpure(t *testing.T) {
+	values := toInterfaces(dummyIntValues)
+
+	calls := 0
+	c := condition.Memo(condition.Check(func(x interface{}) bool {
+		calls++
+		return calls == 1
+	}))
+
+	cache := condition.NewCache()
+	for i := 0; i < 3; i++ {
+		assert.True(t, c.Test(condition.MatchContext{Values: values, Cache: cache}))
+	}
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestMemoCaptures(t *testing.T) {
+	values := []interface{}{"id-42"}
+
+	c := condition.Memo(condition.RegexCapture(`^id-(?P<id>\d+)$`))
+
+	cache := condition.NewCache()
+	assert.True(t, c.Test(condition.MatchContext{Values: values, Cache: cache}))
+
+	captures := make(condition.Captures)
+	assert.True(t, c.Test(condition.MatchContext{Values: values, Cache: cache, Captures: captures}))
+	assert.Equal(t, condition.Captures{"id": "42"}, captures)
+}
+
+func TestMemoConcurrent(t *testing.T) {
+	values := toInterfaces(dummyIntValues)
+
+	c := condition.Memo(condition.Check(condition.Gt(300)))
+	cache := condition.NewCache()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range values {
+				assert.Equal(t, values[i].(int) > 300, c.Test(condition.MatchContext{
+					Values:       values,
+					CurrentIndex: i,
+					Cache:        cache,
+				}))
+			}
+		}()
+	}
+
+	wg.Wait()
+}
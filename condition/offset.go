@@ -0,0 +1,85 @@
+package condition
+
+type (
+	offsetCond[T any] struct {
+		cond   ConditionOf[T]
+		offset int
+		offsetOptions
+	}
+
+	offsetOptions struct {
+		outOfBounds bool
+	}
+
+	OffsetOption func(o *offsetOptions)
+)
+
+// Matches to true if the element right before it satisfies the given condition.
+func Prev(cond Condition, options ...OffsetOption) Condition {
+	return PrevOf(cond, options...)
+}
+
+// Typed variant of Prev.
+func PrevOf[T any](cond ConditionOf[T], options ...OffsetOption) ConditionOf[T] {
+	return AtOf(-1, cond, options...)
+}
+
+// Matches to true if the element right after it satisfies the given condition.
+func Next(cond Condition, options ...OffsetOption) Condition {
+	return NextOf(cond, options...)
+}
+
+// Typed variant of Next.
+func NextOf[T any](cond ConditionOf[T], options ...OffsetOption) ConditionOf[T] {
+	return AtOf(1, cond, options...)
+}
+
+// Matches to true if the element at the given offset from the current element satisfies the given condition,
+// e.g. At(-2, cond) for the element two positions before it.
+//
+// By default, it matches to false if the offset is outside of the slice.
+func At(offset int, cond Condition, options ...OffsetOption) Condition {
+	return AtOf(offset, cond, options...)
+}
+
+// Typed variant of At.
+func AtOf[T any](offset int, cond ConditionOf[T], options ...OffsetOption) ConditionOf[T] {
+	if offset == 0 {
+		panic(ErrInvalidOffset)
+	}
+
+	c := offsetCond[T]{
+		cond:   cond,
+		offset: offset,
+	}
+
+	for _, option := range options {
+		option(&c.offsetOptions)
+	}
+
+	return c
+}
+
+// The result if the offset is outside of the slice, such as Prev for the first element.
+func WithOutOfBounds(outOfBounds bool) OffsetOption {
+	return func(o *offsetOptions) {
+		o.outOfBounds = outOfBounds
+	}
+}
+
+func (c offsetCond[T]) Test(mctx MatchContextOf[T]) bool {
+	j := mctx.CurrentIndex + c.offset
+	if j < 0 || j >= len(mctx.Values) {
+		return c.outOfBounds
+	}
+
+	return c.cond.Test(mctx.at(j))
+}
+
+func (c offsetCond[T]) TestSlice(mctx MatchContextOf[T], mask Bitset) Bitset {
+	// The subcondition is only tested on the elements at the offset from those in the mask.
+	b := TestSliceOf(c.cond, mctx, mask.shift(-c.offset, false)).shift(c.offset, c.outOfBounds)
+	b.And(mask)
+
+	return b
+}
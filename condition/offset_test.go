@@ -0,0 +1,132 @@
+package condition_test
+
+import (
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrev(t *testing.T) {
+	intValues := []int{
+		70,
+		300,
+		70,
+		70,
+		300,
+	}
+
+	c := condition.Prev(condition.Check(condition.Eq(70)))
+
+	test := CondTest{
+		Values:       toInterfaces(intValues),
+		Expectations: makeExpectations(len(intValues), []int{1, 3, 4}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestPrevWithOutOfBounds(t *testing.T) {
+	intValues := []int{
+		70,
+		300,
+		70,
+		70,
+		300,
+	}
+
+	c := condition.Prev(
+		condition.Check(condition.Eq(70)),
+		condition.WithOutOfBounds(true),
+	)
+
+	test := CondTest{
+		Values:       toInterfaces(intValues),
+		Expectations: makeExpectations(len(intValues), []int{0, 1, 3, 4}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestNext(t *testing.T) {
+	intValues := []int{
+		70,
+		300,
+		70,
+		70,
+		300,
+	}
+
+	c := condition.Next(condition.Check(condition.Eq(300)))
+
+	test := CondTest{
+		Values:       toInterfaces(intValues),
+		Expectations: makeExpectations(len(intValues), []int{0, 3}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestNextWithOutOfBounds(t *testing.T) {
+	intValues := []int{
+		70,
+		300,
+		70,
+		70,
+		300,
+	}
+
+	c := condition.Next(
+		condition.Check(condition.Eq(300)),
+		condition.WithOutOfBounds(true),
+	)
+
+	test := CondTest{
+		Values:       toInterfaces(intValues),
+		Expectations: makeExpectations(len(intValues), []int{0, 3, 4}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestAt(t *testing.T) {
+	intValues := []int{
+		70,
+		300,
+		70,
+		70,
+		300,
+	}
+
+	c := condition.At(-2, condition.Check(condition.Eq(70)))
+
+	test := CondTest{
+		Values:       toInterfaces(intValues),
+		Expectations: makeExpectations(len(intValues), []int{2, 4}),
+	}
+
+	testCond(t, c, test)
+
+	c = condition.At(3, condition.Check(condition.Eq(300)), condition.WithOutOfBounds(true))
+
+	test = CondTest{
+		Values:       toInterfaces(intValues),
+		Expectations: makeExpectations(len(intValues), []int{1, 2, 3, 4}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestPrevOf(t *testing.T) {
+	c := condition.PrevOf(condition.CheckOf(func(x int) bool {
+		return x > 300
+	}))
+
+	testCondOf(t, c, dummyIntValues, makeExpectations(len(dummyIntValues), []int{1, 2, 4, 9}))
+}
+
+func TestAtPanicInvalidOffset(t *testing.T) {
+	assert.PanicsWithError(t, condition.ErrInvalidOffset.Error(), func() {
+		condition.At(0, condition.Check(condition.Eq(0)))
+	})
+}
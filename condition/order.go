@@ -0,0 +1,236 @@
+package condition
+
+import (
+	"math"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Greater than check with the given value.
+//
+// Integers, unsigned integers, floats, strings, and time.Time (including named types of them) are supported.
+// Numbers of different kinds are compared by their numeric value.
+// The check fails for values that cannot be compared with the given value.
+func Gt(val interface{}) CheckFunc {
+	return orderCheck(val, func(cmp int) bool {
+		return cmp > 0
+	})
+}
+
+// Greater than or equal check with the given value.
+//
+// See Gt for the supported values.
+func Gte(val interface{}) CheckFunc {
+	return orderCheck(val, func(cmp int) bool {
+		return cmp >= 0
+	})
+}
+
+// Less than check with the given value.
+//
+// See Gt for the supported values.
+func Lt(val interface{}) CheckFunc {
+	return orderCheck(val, func(cmp int) bool {
+		return cmp < 0
+	})
+}
+
+// Less than or equal check with the given value.
+//
+// See Gt for the supported values.
+func Lte(val interface{}) CheckFunc {
+	return orderCheck(val, func(cmp int) bool {
+		return cmp <= 0
+	})
+}
+
+// Inclusive range check between the given values.
+//
+// See Gt for the supported values.
+func Between(lo, hi interface{}) CheckFunc {
+	gte, lte := Gte(lo), Lte(hi)
+	return func(x interface{}) bool {
+		return gte(x) && lte(x)
+	}
+}
+
+// Exclusive range check between the given values.
+//
+// See Gt for the supported values.
+func BetweenExclusive(lo, hi interface{}) CheckFunc {
+	gt, lt := Gt(lo), Lt(hi)
+	return func(x interface{}) bool {
+		return gt(x) && lt(x)
+	}
+}
+
+func orderCheck(val interface{}, fn func(cmp int) bool) CheckFunc {
+	rval := reflect.ValueOf(val)
+	return func(x interface{}) bool {
+		cmp, ok := compare(reflect.ValueOf(x), rval)
+		if !ok {
+			return false
+		}
+
+		return fn(cmp)
+	}
+}
+
+// Compare two values, returning -1, 0, or 1 if a is respectively less than, equal to, or greater than b.
+// The second return value is false if both values cannot be compared.
+func compare(a, b reflect.Value) (int, bool) {
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+
+	if isTime(a) && isTime(b) {
+		return compareTime(a.Convert(timeType).Interface().(time.Time), b.Convert(timeType).Interface().(time.Time)), true
+	}
+
+	switch {
+	case isString(a) && isString(b):
+		return compareOrdered(a.String(), b.String()), true
+	case isInt(a):
+		switch {
+		case isInt(b):
+			return compareOrdered(a.Int(), b.Int()), true
+		case isUint(b):
+			return compareIntUint(a.Int(), b.Uint()), true
+		case isFloat(b):
+			return compareIntFloat(a.Int(), b.Float())
+		}
+	case isUint(a):
+		switch {
+		case isInt(b):
+			return -compareIntUint(b.Int(), a.Uint()), true
+		case isUint(b):
+			return compareOrdered(a.Uint(), b.Uint()), true
+		case isFloat(b):
+			return compareUintFloat(a.Uint(), b.Float())
+		}
+	case isFloat(a):
+		switch {
+		case isInt(b):
+			cmp, ok := compareIntFloat(b.Int(), a.Float())
+			return -cmp, ok
+		case isUint(b):
+			cmp, ok := compareUintFloat(b.Uint(), a.Float())
+			return -cmp, ok
+		case isFloat(b):
+			af, bf := a.Float(), b.Float()
+			if math.IsNaN(af) || math.IsNaN(bf) {
+				return 0, false
+			}
+
+			return compareOrdered(af, bf), true
+		}
+	}
+
+	return 0, false
+}
+
+func isTime(rv reflect.Value) bool {
+	return rv.Kind() == reflect.Struct && rv.Type().ConvertibleTo(timeType)
+}
+
+func isString(rv reflect.Value) bool {
+	return rv.Kind() == reflect.String
+}
+
+func isInt(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUint(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloat(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func compareOrdered[T int64 | uint64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareIntUint(a int64, b uint64) int {
+	if a < 0 {
+		return -1
+	}
+
+	return compareOrdered(uint64(a), b)
+}
+
+// Compare an integer with a float without losing precision on large integers.
+func compareIntFloat(a int64, b float64) (int, bool) {
+	switch {
+	case math.IsNaN(b):
+		return 0, false
+	case b >= math.MaxInt64:
+		return -1, true
+	case b < math.MinInt64:
+		return 1, true
+	}
+
+	t := math.Trunc(b)
+	if cmp := compareOrdered(a, int64(t)); cmp != 0 {
+		return cmp, true
+	}
+
+	// The integer equals the integral part, so the fractional part decides.
+	return compareOrdered(t, b), true
+}
+
+// Compare an unsigned integer with a float without losing precision on large integers.
+func compareUintFloat(a uint64, b float64) (int, bool) {
+	switch {
+	case math.IsNaN(b):
+		return 0, false
+	case b < 0:
+		return 1, true
+	case b >= math.MaxUint64:
+		return -1, true
+	}
+
+	t := math.Trunc(b)
+	if cmp := compareOrdered(a, uint64(t)); cmp != 0 {
+		return cmp, true
+	}
+
+	return compareOrdered(t, b), true
+}
@@ -0,0 +1,191 @@
+package condition_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ezraisw/conma/condition"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	exampleNamedInt    int
+	exampleNamedString string
+)
+
+func TestCheckGt(t *testing.T) {
+	c := condition.Check(condition.Gt(100))
+
+	values := []interface{}{
+		99,
+		100,
+		101,
+		int8(-100),
+		uint8(200),
+		100.5,
+		float32(99.5),
+		exampleNamedInt(150),
+		"101",
+		nil,
+		math.NaN(),
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{2, 4, 5, 7}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckGte(t *testing.T) {
+	c := condition.Check(condition.Gte(100.0))
+
+	values := []interface{}{
+		99,
+		100,
+		uint64(100),
+		int64(math.MaxInt64),
+		uint64(math.MaxUint64),
+		99.99,
+		"100",
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{1, 2, 3, 4}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckLt(t *testing.T) {
+	c := condition.Check(condition.Lt(uint(10)))
+
+	values := []interface{}{
+		-1,
+		int64(math.MinInt64),
+		9.99,
+		10,
+		uint(11),
+		float32(10),
+		exampleNamedString("9"),
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 1, 2}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckLte(t *testing.T) {
+	c := condition.Check(condition.Lte("m"))
+
+	values := []interface{}{
+		"a",
+		"m",
+		"z",
+		exampleNamedString("b"),
+		exampleNamedString("n"),
+		1,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 1, 3}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckOrderTime(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	c := condition.Check(condition.Gt(now))
+
+	values := []interface{}{
+		now.Add(-time.Hour),
+		now,
+		now.Add(time.Hour),
+		now.Unix(),
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{2}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckOrderLargeInteger(t *testing.T) {
+	// 2^53 + 1 cannot be represented exactly by a float64.
+	large := int64(1<<53 + 1)
+
+	assert.True(t, condition.Gt(float64(1<<53))(large))
+	assert.False(t, condition.Lte(float64(1<<53))(large))
+	assert.True(t, condition.Lt(large)(float64(1<<53)))
+	assert.True(t, condition.Gt(uint64(1<<53))(large))
+	assert.False(t, condition.Gt(math.Inf(1))(int64(math.MaxInt64)))
+	assert.True(t, condition.Gt(math.Inf(-1))(int64(math.MinInt64)))
+}
+
+func TestCheckBetween(t *testing.T) {
+	c := condition.Check(condition.Between(10, 20.5))
+
+	values := []interface{}{
+		9,
+		10,
+		15.5,
+		uint16(20),
+		20.5,
+		21,
+		"15",
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{1, 2, 3, 4}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckBetweenExclusive(t *testing.T) {
+	c := condition.Check(condition.BetweenExclusive(10, 20.5))
+
+	values := []interface{}{
+		9,
+		10,
+		15.5,
+		uint16(20),
+		20.5,
+		21,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{2, 3}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCheckGt(t *testing.T) {
+	c := condition.FieldCheck("Field1.Field2", condition.Gt(100.5))
+
+	values := []interface{}{
+		dummyStructValues[0],
+		dummyStructValues[1],
+		dummyStructValues[2],
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0}),
+	}
+
+	testCond(t, c, test)
+}
@@ -0,0 +1,89 @@
+package condition
+
+type positionCond[T any] struct {
+	fn func(index int, n int) bool
+}
+
+// Matches to true if the element is the first of the slice.
+func IsFirst() Condition {
+	return IsFirstOf[interface{}]()
+}
+
+// Typed variant of IsFirst.
+func IsFirstOf[T any]() ConditionOf[T] {
+	return positionCond[T]{
+		fn: func(index int, n int) bool {
+			return index == 0
+		},
+	}
+}
+
+// Matches to true if the element is the last of the slice.
+func IsLast() Condition {
+	return IsLastOf[interface{}]()
+}
+
+// Typed variant of IsLast.
+func IsLastOf[T any]() ConditionOf[T] {
+	return FromEndOf[T](0)
+}
+
+// Matches to true if the index of the element is between lo and hi, inclusive.
+func IndexBetween(lo int, hi int) Condition {
+	return IndexBetweenOf[interface{}](lo, hi)
+}
+
+// Typed variant of IndexBetween.
+func IndexBetweenOf[T any](lo int, hi int) ConditionOf[T] {
+	if lo < 0 || hi < lo {
+		panic(ErrInvalidIndexRange)
+	}
+
+	return positionCond[T]{
+		fn: func(index int, n int) bool {
+			return index >= lo && index <= hi
+		},
+	}
+}
+
+// Matches to true if the index of the element modulo n equals r,
+// e.g. IndexMod(2, 1) for every odd element.
+func IndexMod(n int, r int) Condition {
+	return IndexModOf[interface{}](n, r)
+}
+
+// Typed variant of IndexMod.
+func IndexModOf[T any](n int, r int) ConditionOf[T] {
+	if n <= 0 || r < 0 || r >= n {
+		panic(ErrInvalidModulus)
+	}
+
+	return positionCond[T]{
+		fn: func(index int, _ int) bool {
+			return index%n == r
+		},
+	}
+}
+
+// Matches to true if the element is k elements away from the last element,
+// e.g. FromEnd(0) for the last element and FromEnd(1) for the one before it.
+func FromEnd(k int) Condition {
+	return FromEndOf[interface{}](k)
+}
+
+// Typed variant of FromEnd.
+func FromEndOf[T any](k int) ConditionOf[T] {
+	if k < 0 {
+		panic(ErrInvalidIndexRange)
+	}
+
+	return positionCond[T]{
+		fn: func(index int, n int) bool {
+			return index == n-1-k
+		},
+	}
+}
+
+func (c positionCond[T]) Test(mctx MatchContextOf[T]) bool {
+	return c.fn(mctx.CurrentIndex, len(mctx.Values))
+}
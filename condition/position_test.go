@@ -0,0 +1,84 @@
+package condition_test
+
+import (
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsFirst(t *testing.T) {
+	test := CondTest{
+		Values:       toInterfaces(dummyIntValues),
+		Expectations: makeExpectations(len(dummyIntValues), []int{0}),
+	}
+
+	testCond(t, condition.IsFirst(), test)
+}
+
+func TestIsLast(t *testing.T) {
+	test := CondTest{
+		Values:       toInterfaces(dummyIntValues),
+		Expectations: makeExpectations(len(dummyIntValues), []int{9}),
+	}
+
+	testCond(t, condition.IsLast(), test)
+}
+
+func TestIndexBetween(t *testing.T) {
+	test := CondTest{
+		Values:       toInterfaces(dummyIntValues),
+		Expectations: makeExpectations(len(dummyIntValues), []int{2, 3, 4}),
+	}
+
+	testCond(t, condition.IndexBetween(2, 4), test)
+}
+
+func TestIndexMod(t *testing.T) {
+	test := CondTest{
+		Values:       toInterfaces(dummyIntValues),
+		Expectations: makeExpectations(len(dummyIntValues), []int{1, 4, 7}),
+	}
+
+	testCond(t, condition.IndexMod(3, 1), test)
+}
+
+func TestFromEnd(t *testing.T) {
+	test := CondTest{
+		Values:       toInterfaces(dummyIntValues),
+		Expectations: makeExpectations(len(dummyIntValues), []int{7}),
+	}
+
+	testCond(t, condition.FromEnd(2), test)
+}
+
+func TestPositionOf(t *testing.T) {
+	c := condition.OrOf(
+		condition.IsFirstOf[int](),
+		condition.IsLastOf[int](),
+	)
+
+	testCondOf(t, c, dummyIntValues, makeExpectations(len(dummyIntValues), []int{0, 9}))
+}
+
+func TestPositionPanics(t *testing.T) {
+	assert.PanicsWithError(t, condition.ErrInvalidIndexRange.Error(), func() {
+		condition.IndexBetween(3, 2)
+	})
+
+	assert.PanicsWithError(t, condition.ErrInvalidIndexRange.Error(), func() {
+		condition.IndexBetween(-1, 2)
+	})
+
+	assert.PanicsWithError(t, condition.ErrInvalidIndexRange.Error(), func() {
+		condition.FromEnd(-1)
+	})
+
+	assert.PanicsWithError(t, condition.ErrInvalidModulus.Error(), func() {
+		condition.IndexMod(0, 0)
+	})
+
+	assert.PanicsWithError(t, condition.ErrInvalidModulus.Error(), func() {
+		condition.IndexMod(2, 2)
+	})
+}
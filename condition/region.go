@@ -0,0 +1,138 @@
+package condition
+
+import "sync"
+
+type (
+	regionCond[T any] struct {
+		open  ConditionOf[T]
+		close ConditionOf[T]
+		regionOptions
+	}
+
+	regionOptions struct {
+		inclusive bool
+		nested    bool
+	}
+
+	RegionOption func(o *regionOptions)
+
+	// Whether the elements computed so far are inside a region, and the depth of the regions after them.
+	regionState struct {
+		mu     sync.Mutex
+		inside []bool
+		depth  int
+	}
+)
+
+// Matches to true if the element is inside a region opened by an element satisfying open
+// and closed by a later element satisfying close.
+// The marker elements themselves are not inside the region unless WithInclusive is used.
+// A region which is never closed extends to the end of the slice.
+//
+// By default, regions do not nest and the first element satisfying close ends the region.
+// If an element satisfies both conditions, it closes the region it is in, if any, and opens one otherwise.
+//
+// The regions are computed once per slice if the match context has a cache,
+// only testing the markers up to the elements matched so far.
+func Within(open Condition, close Condition, options ...RegionOption) Condition {
+	return WithinOf(open, close, options...)
+}
+
+// Typed variant of Within.
+func WithinOf[T any](open ConditionOf[T], close ConditionOf[T], options ...RegionOption) ConditionOf[T] {
+	c := &regionCond[T]{
+		open:  open,
+		close: close,
+	}
+
+	for _, option := range options {
+		option(&c.regionOptions)
+	}
+
+	return c
+}
+
+// The marker elements opening and closing a region are inside the region as well.
+func WithInclusive(inclusive bool) RegionOption {
+	return func(o *regionOptions) {
+		o.inclusive = inclusive
+	}
+}
+
+// Regions may be nested by counting the depth of the markers,
+// so that a region is only closed by the marker matching its opening marker.
+func WithNested(nested bool) RegionOption {
+	return func(o *regionOptions) {
+		o.nested = nested
+	}
+}
+
+func (c *regionCond[T]) Test(mctx MatchContextOf[T]) bool {
+	return c.insideUpTo(mctx, mctx.CurrentIndex)[mctx.CurrentIndex]
+}
+
+func (c *regionCond[T]) TestSlice(mctx MatchContextOf[T], mask Bitset) Bitset {
+	b := NewBitset(len(mctx.Values))
+
+	last := -1
+	for i := mask.next(0); i >= 0; i = mask.next(i + 1) {
+		last = i
+	}
+
+	if last < 0 {
+		return b
+	}
+
+	inside := c.insideUpTo(mctx, last)
+	for i := mask.next(0); i >= 0; i = mask.next(i + 1) {
+		if inside[i] {
+			b.Set(i)
+		}
+	}
+
+	return b
+}
+
+// Obtain whether each element up to the given index is inside a region.
+// Like a scan, the markers are only tested up to the index, extending the elements stored in the cache if any.
+func (c *regionCond[T]) insideUpTo(mctx MatchContextOf[T], index int) []bool {
+	if mctx.Cache == nil {
+		state := &regionState{}
+		c.extend(mctx, state, index)
+
+		return state.inside
+	}
+
+	state := mctx.Cache.Load(c, func() interface{} {
+		return &regionState{}
+	}).(*regionState)
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	c.extend(mctx, state, index)
+
+	// Stored elements are never changed, so they can be read once unlocked.
+	return state.inside
+}
+
+// Compute whether each element is inside a region, from the first element not computed yet up to the given index.
+func (c *regionCond[T]) extend(mctx MatchContextOf[T], state *regionState, index int) {
+	for i := len(state.inside); i <= index; i++ {
+		submctx := mctx.at(i)
+
+		var inside bool
+		switch {
+		case state.depth > 0 && c.close.Test(submctx):
+			state.depth--
+			inside = state.depth > 0 || c.inclusive
+		case (state.depth == 0 || c.nested) && c.open.Test(submctx):
+			inside = state.depth > 0 || c.inclusive
+			state.depth++
+		default:
+			inside = state.depth > 0
+		}
+
+		state.inside = append(state.inside, inside)
+	}
+}
@@ -0,0 +1,143 @@
+package condition_test
+
+import (
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	regionValues = []interface{}{"a", "(", "b", ")", "c", "(", "d"}
+	nestedValues = []interface{}{"(", "a", "(", "b", ")", "c", ")", "d"}
+
+	openCond  = condition.Check(condition.Eq("("))
+	closeCond = condition.Check(condition.Eq(")"))
+)
+
+func TestWithin(t *testing.T) {
+	c := condition.Within(openCond, closeCond)
+
+	test := CondTest{
+		Values:       regionValues,
+		Expectations: makeExpectations(len(regionValues), []int{2, 6}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestWithinInclusive(t *testing.T) {
+	c := condition.Within(openCond, closeCond, condition.WithInclusive(true))
+
+	test := CondTest{
+		Values:       regionValues,
+		Expectations: makeExpectations(len(regionValues), []int{1, 2, 3, 5, 6}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestWithinNotNested(t *testing.T) {
+	c := condition.Within(openCond, closeCond)
+
+	test := CondTest{
+		Values:       nestedValues,
+		Expectations: makeExpectations(len(nestedValues), []int{1, 2, 3}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestWithinNested(t *testing.T) {
+	c := condition.Within(openCond, closeCond, condition.WithNested(true))
+
+	test := CondTest{
+		Values:       nestedValues,
+		Expectations: makeExpectations(len(nestedValues), []int{1, 2, 3, 4, 5}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestWithinNestedInclusive(t *testing.T) {
+	c := condition.Within(
+		openCond,
+		closeCond,
+		condition.WithNested(true),
+		condition.WithInclusive(true),
+	)
+
+	test := CondTest{
+		Values:       nestedValues,
+		Expectations: makeExpectations(len(nestedValues), []int{0, 1, 2, 3, 4, 5, 6}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestWithinSameMarker(t *testing.T) {
+	values := []interface{}{"a", "|", "b", "|", "c"}
+	marker := condition.Check(condition.Eq("|"))
+
+	c := condition.Within(marker, marker)
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{2}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestWithinCache(t *testing.T) {
+	calls := 0
+	open := condition.Check(func(val interface{}) bool {
+		calls++
+		return val == "("
+	})
+
+	c := condition.Within(open, closeCond)
+
+	cache := condition.NewCache()
+
+	matched := make([]int, 0)
+	for i := range regionValues {
+		mctx := condition.MatchContext{
+			Values:       regionValues,
+			CurrentIndex: i,
+			Cache:        cache,
+		}
+
+		if c.Test(mctx) {
+			matched = append(matched, i)
+		}
+	}
+
+	assert.Equal(t, []int{2, 6}, matched)
+	assert.LessOrEqual(t, calls, len(regionValues))
+}
+
+func TestWithinMixedValues(t *testing.T) {
+	values := []interface{}{"open", "x", "close", 5}
+
+	isString := condition.Check(condition.IsType[string]())
+	c := condition.And(
+		isString,
+		condition.Within(
+			condition.Check(func(x interface{}) bool { return x.(string) == "open" }),
+			condition.Check(func(x interface{}) bool { return x.(string) == "close" }),
+		),
+	)
+
+	// Like without a cache, the markers are never tested on the last element.
+	for _, cache := range []*condition.Cache{nil, condition.NewCache()} {
+		for i, expected := range []bool{false, true, false, false} {
+			assert.Equal(t, expected, c.Test(condition.MatchContext{Values: values, CurrentIndex: i, Cache: cache}), "Index: %d", i)
+		}
+
+		mask := condition.NewFullBitset(len(values))
+		b := condition.TestSlice(c, condition.MatchContext{Values: values, Cache: cache}, mask)
+		assert.Equal(t, 1, b.Count())
+		assert.True(t, b.Test(1))
+	}
+}
@@ -0,0 +1,125 @@
+package condition
+
+import "reflect"
+
+type (
+	fieldCompareCond[T any] struct {
+		left  fieldPath
+		right fieldPath
+		cmp   Comparator
+	}
+
+	fieldRelationCond[T any] struct {
+		path fieldPath
+
+		// The checks built from the values of the current element's field.
+		checks []CheckFunc
+	}
+
+	// Builds a check comparing a value against the given value.
+	//
+	// Eq, DeepEq, Gt, Gte, Lt, and Lte are ready-made comparators.
+	Comparator func(val interface{}) CheckFunc
+)
+
+// Matches to true if a struct element's left field compares to its right field with the given comparator,
+// e.g. FieldCompare("EndDate", Gt, "StartDate").
+// Both targets follow the same format as FieldCheck.
+func FieldCompare(left string, cmp Comparator, right string, options ...FieldOption) Condition {
+	return FieldCompareOf[interface{}](left, cmp, right, options...)
+}
+
+// Typed variant of FieldCompare.
+func FieldCompareOf[T any](left string, cmp Comparator, right string, options ...FieldOption) ConditionOf[T] {
+	return fieldCompareCond[T]{
+		left:  parseFieldPath(left, options),
+		right: parseFieldPath(right, options),
+		cmp:   cmp,
+	}
+}
+
+func (c fieldCompareCond[T]) Test(mctx MatchContextOf[T]) bool {
+	return testField(mctx, c.right, func(rrv reflect.Value) bool {
+		fn := c.cmp(rrv.Interface())
+
+		return testField(mctx, c.left, func(lrv reflect.Value) bool {
+			return fn(lrv.Interface())
+		})
+	})
+}
+
+// Lookaround condition function matching the elements whose field equals the same field of the current element,
+// e.g. Lookaround(SameField("Code"), 1) for a later element with the same code.
+// The fields are compared with the same semantics as DeepEq.
+func SameField(target string, options ...FieldOption) LookaroundCondFunc {
+	return SameFieldOf[interface{}](target, options...)
+}
+
+// Typed variant of SameField.
+func SameFieldOf[T any](target string, options ...FieldOption) LookaroundCondFuncOf[T] {
+	return FieldRelationToOf[T](target, DeepEq, target, options...)
+}
+
+// Lookaround condition function matching the elements whose field compares to the same field of the current element
+// with the given comparator, e.g. Lookaround(FieldRelation("Seq", Gt), -1) for an earlier element with a greater sequence.
+func FieldRelation(target string, cmp Comparator, options ...FieldOption) LookaroundCondFunc {
+	return FieldRelationOf[interface{}](target, cmp, options...)
+}
+
+// Typed variant of FieldRelation.
+func FieldRelationOf[T any](target string, cmp Comparator, options ...FieldOption) LookaroundCondFuncOf[T] {
+	return FieldRelationToOf[T](target, cmp, target, options...)
+}
+
+// Lookaround condition function matching the elements whose field compares to another field of the current element
+// with the given comparator, e.g. Lookaround(FieldRelationTo("StartDate", Gte, "EndDate"), 1).
+func FieldRelationTo(target string, cmp Comparator, currentTarget string, options ...FieldOption) LookaroundCondFunc {
+	return FieldRelationToOf[interface{}](target, cmp, currentTarget, options...)
+}
+
+// Typed variant of FieldRelationTo.
+func FieldRelationToOf[T any](target string, cmp Comparator, currentTarget string, options ...FieldOption) LookaroundCondFuncOf[T] {
+	path := parseFieldPath(target, options)
+	currentPath := parseFieldPath(currentTarget, options)
+
+	return func(x T) ConditionOf[T] {
+		// Resolve every value of the current field, regardless of the wildcard semantics.
+		anyPath := currentPath
+		anyPath.wildcardAll = false
+
+		var checks []CheckFunc
+		anyPath.test(reflect.ValueOf(&x).Elem(), anyPath.segments, func(rv reflect.Value) bool {
+			checks = append(checks, cmp(rv.Interface()))
+			return false
+		})
+
+		return fieldRelationCond[T]{
+			path:   path,
+			checks: checks,
+		}
+	}
+}
+
+func (c fieldRelationCond[T]) Test(mctx MatchContextOf[T]) bool {
+	return testField(mctx, c.path, func(rv reflect.Value) bool {
+		val := rv.Interface()
+
+		if c.path.wildcardAll {
+			for _, check := range c.checks {
+				if !check(val) {
+					return false
+				}
+			}
+
+			return len(c.checks) != 0
+		}
+
+		for _, check := range c.checks {
+			if check(val) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
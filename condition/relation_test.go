@@ -0,0 +1,194 @@
+package condition_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ezraisw/conma/condition"
+)
+
+type exampleAddress struct {
+	Country string
+}
+
+type exampleBooking struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Billing   exampleAddress
+	Shipping  *exampleAddress
+	Nights    int
+	MinNights float64
+}
+
+var bookingStart = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+var dummyBookingValues = []exampleBooking{
+	{
+		StartDate: bookingStart,
+		EndDate:   bookingStart.Add(48 * time.Hour),
+		Billing:   exampleAddress{Country: "ID"},
+		Shipping:  &exampleAddress{Country: "ID"},
+		Nights:    2,
+		MinNights: 1.5,
+	},
+	{
+		StartDate: bookingStart,
+		EndDate:   bookingStart,
+		Billing:   exampleAddress{Country: "ID"},
+		Shipping:  &exampleAddress{Country: "SG"},
+		Nights:    0,
+		MinNights: 1,
+	},
+	{
+		StartDate: bookingStart,
+		EndDate:   bookingStart.Add(-24 * time.Hour),
+		Billing:   exampleAddress{Country: "SG"},
+		Shipping:  nil,
+		Nights:    1,
+		MinNights: 1,
+	},
+}
+
+func TestFieldCompareGt(t *testing.T) {
+	c := condition.FieldCompare("EndDate", condition.Gt, "StartDate")
+
+	test := CondTest{
+		Values:       toInterfaces(dummyBookingValues),
+		Expectations: makeExpectations(len(dummyBookingValues), []int{0}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCompareGte(t *testing.T) {
+	c := condition.FieldCompare("Nights", condition.Gte, "MinNights")
+
+	test := CondTest{
+		Values:       toInterfaces(dummyBookingValues),
+		Expectations: makeExpectations(len(dummyBookingValues), []int{0, 2}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCompareEq(t *testing.T) {
+	c := condition.FieldCompare("Billing.Country", condition.Eq, "Shipping.Country")
+
+	test := CondTest{
+		Values:       toInterfaces(dummyBookingValues),
+		Expectations: makeExpectations(len(dummyBookingValues), []int{0}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCompareWildcard(t *testing.T) {
+	c := condition.FieldCompare("Items.*.Price", condition.Gte, "Totals.1")
+
+	test := CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{0, 1}),
+	}
+
+	testCond(t, c, test)
+
+	c = condition.FieldCompare("Items.*.Price", condition.Gte, "Totals.1", condition.WithWildcardAll(true))
+
+	test = CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{0, 1}),
+	}
+
+	testCond(t, c, test)
+
+	c = condition.FieldCompare("Items.*.Price", condition.Gte, "Totals.0", condition.WithWildcardAll(true))
+
+	test = CondTest{
+		Values:       toInterfaces(dummyOrderValues),
+		Expectations: makeExpectations(len(dummyOrderValues), []int{1}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCompareOf(t *testing.T) {
+	c := condition.FieldCompareOf[exampleBooking]("EndDate", condition.Lt, "StartDate")
+
+	testCondOf(t, c, dummyBookingValues, makeExpectations(len(dummyBookingValues), []int{2}))
+}
+
+type exampleRecord struct {
+	Code    string
+	Seq     int
+	Parent  int
+	Comment string
+}
+
+var dummyRecordValues = []exampleRecord{
+	{Code: "A", Seq: 1},
+	{Code: "B", Seq: 2},
+	{Code: "A", Seq: 3, Parent: 1},
+	{Code: "C", Seq: 2, Parent: 2},
+	{Code: "B", Seq: 5, Parent: 3},
+}
+
+func TestLookaroundSameField(t *testing.T) {
+	c := condition.Lookaround(condition.SameField("Code"), 1)
+
+	test := CondTest{
+		Values:       toInterfaces(dummyRecordValues),
+		Expectations: makeExpectations(len(dummyRecordValues), []int{0, 1}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestLookaroundSameFieldWithMaxDist(t *testing.T) {
+	c := condition.Lookaround(condition.SameField("Code"), -1, condition.WithMaxDist(2))
+
+	test := CondTest{
+		Values:       toInterfaces(dummyRecordValues),
+		Expectations: makeExpectations(len(dummyRecordValues), []int{2}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestLookaroundFieldRelation(t *testing.T) {
+	c := condition.Lookaround(condition.FieldRelation("Seq", condition.Gte), -1)
+
+	test := CondTest{
+		Values:       toInterfaces(dummyRecordValues),
+		Expectations: makeExpectations(len(dummyRecordValues), []int{3}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestLookaroundFieldRelationWithAll(t *testing.T) {
+	c := condition.Lookaround(condition.FieldRelation("Seq", condition.Lt), -1, condition.WithAll(true))
+
+	test := CondTest{
+		Values:       toInterfaces(dummyRecordValues),
+		Expectations: makeExpectations(len(dummyRecordValues), []int{1, 2, 4}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestLookaroundFieldRelationTo(t *testing.T) {
+	c := condition.Lookaround(condition.FieldRelationTo("Seq", condition.Eq, "Parent"), -1)
+
+	test := CondTest{
+		Values:       toInterfaces(dummyRecordValues),
+		Expectations: makeExpectations(len(dummyRecordValues), []int{2, 3, 4}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestLookaroundSameFieldOf(t *testing.T) {
+	c := condition.LookaroundOf(condition.SameFieldOf[exampleRecord]("Code"), 1, condition.WithStartDist(2))
+
+	testCondOf(t, c, dummyRecordValues, makeExpectations(len(dummyRecordValues), []int{0, 1}))
+}
@@ -0,0 +1,92 @@
+package condition
+
+import "reflect"
+
+// Set of values with hashed lookup for comparable values.
+type valueSet struct {
+	hashed map[interface{}]struct{}
+
+	// Values which cannot be hashed, compared with deep equality instead.
+	others []interface{}
+}
+
+// Membership check within the given values.
+//
+// Comparable values are looked up in a hash set with the same semantics as Eq,
+// while the other values fall back to the same semantics as DeepEq.
+func In(values ...interface{}) CheckFunc {
+	set := newValueSet(values)
+	return set.contains
+}
+
+// Negated membership check within the given values.
+//
+// See In for the semantics of the check.
+func NotIn(values ...interface{}) CheckFunc {
+	set := newValueSet(values)
+	return func(x interface{}) bool {
+		return !set.contains(x)
+	}
+}
+
+func newValueSet(values []interface{}) valueSet {
+	set := valueSet{
+		hashed: make(map[interface{}]struct{}, len(values)),
+	}
+
+	for _, val := range values {
+		if !set.add(val) {
+			set.others = append(set.others, val)
+		}
+	}
+
+	return set
+}
+
+func (s valueSet) contains(x interface{}) bool {
+	if s.lookup(x) {
+		return true
+	}
+
+	for _, val := range s.others {
+		if reflect.DeepEqual(val, x) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Add the value to the hash set, failing if the value cannot be hashed.
+func (s valueSet) add(x interface{}) (ok bool) {
+	if x != nil && !reflect.TypeOf(x).Comparable() {
+		return false
+	}
+
+	// Comparable types may still hold uncomparable values within their interface fields,
+	// which only panics once hashed.
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	s.hashed[x] = struct{}{}
+	return true
+}
+
+// Look the value up in the hash set, failing if the value cannot be hashed.
+func (s valueSet) lookup(x interface{}) (ok bool) {
+	if x != nil && !reflect.TypeOf(x).Comparable() {
+		return false
+	}
+
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	_, ok = s.hashed[x]
+	return ok
+}
@@ -0,0 +1,116 @@
+package condition_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+)
+
+type exampleComparableStruct struct {
+	Field1 interface{}
+}
+
+func TestCheckIn(t *testing.T) {
+	c := condition.Check(condition.In(
+		dummyIntValues[0],
+		dummyStringValues[0],
+		dummyStructValues[0],
+		[]int{1, 2, 3},
+		exampleComparableStruct{Field1: []int{1}},
+		nil,
+	))
+
+	values := []interface{}{
+		dummyIntValues[0],
+		dummyIntValues[1],
+		int64(dummyIntValues[0]),
+		dummyStringValues[0],
+		dummyStringValues[1],
+		dummyStructValues[0],
+		dummyStructValues[1],
+		[]int{1, 2, 3},
+		[]int{1, 2},
+		exampleComparableStruct{Field1: []int{1}},
+		exampleComparableStruct{Field1: []int{2}},
+		nil,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 3, 5, 7, 9, 11}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckNotIn(t *testing.T) {
+	c := condition.Check(condition.NotIn(
+		dummyIntValues[0],
+		dummyStringValues[0],
+		[]int{1, 2, 3},
+	))
+
+	values := []interface{}{
+		dummyIntValues[0],
+		dummyIntValues[1],
+		dummyStringValues[0],
+		dummyStringValues[1],
+		[]int{1, 2, 3},
+		[]int{1, 2},
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{1, 3, 5}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestFieldCheckIn(t *testing.T) {
+	c := condition.FieldCheck("Field1.Field1", condition.In("empty", "unknown"))
+
+	values := []interface{}{
+		dummyStructValues[0],
+		dummyStructValues[1],
+		dummyStructValues[2],
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{1}),
+	}
+
+	testCond(t, c, test)
+}
+
+func BenchmarkCheckIn(b *testing.B) {
+	codes := make([]interface{}, 0, 500)
+	for i := 0; i < 500; i++ {
+		codes = append(codes, fmt.Sprintf("CODE-%d", i))
+	}
+
+	check := condition.In(codes...)
+	for i := 0; i < b.N; i++ {
+		check(codes[i%len(codes)])
+	}
+}
+
+func BenchmarkCheckOrEq(b *testing.B) {
+	codes := make([]interface{}, 0, 500)
+	conds := make([]condition.Condition, 0, 500)
+	for i := 0; i < 500; i++ {
+		code := fmt.Sprintf("CODE-%d", i)
+		codes = append(codes, code)
+		conds = append(conds, condition.Check(condition.Eq(code)))
+	}
+
+	c := condition.Or(conds...)
+	for i := 0; i < b.N; i++ {
+		c.Test(condition.MatchContext{
+			Values:       codes,
+			CurrentIndex: i % len(codes),
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package condition
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Regular expression check with the given pattern.
+// The pattern is compiled once and panics if it is invalid.
+//
+// Like the other string checks, named string types are supported
+// and the check fails for values that are not strings.
+func Regex(pattern string) CheckFunc {
+	re := regexp.MustCompile(pattern)
+	return stringCheck(re.MatchString)
+}
+
+// Prefix check with the given string.
+func HasPrefix(prefix string) CheckFunc {
+	return stringCheck(func(s string) bool {
+		return strings.HasPrefix(s, prefix)
+	})
+}
+
+// Suffix check with the given string.
+func HasSuffix(suffix string) CheckFunc {
+	return stringCheck(func(s string) bool {
+		return strings.HasSuffix(s, suffix)
+	})
+}
+
+// Substring check with the given string.
+func Contains(substr string) CheckFunc {
+	return stringCheck(func(s string) bool {
+		return strings.Contains(s, substr)
+	})
+}
+
+// Case-insensitive equality check with the given string, using Unicode case-folding.
+func EqualFold(str string) CheckFunc {
+	return stringCheck(func(s string) bool {
+		return strings.EqualFold(s, str)
+	})
+}
+
+// Glob check with the given pattern, matching the whole string.
+//
+// The pattern supports '*' for any sequence of characters, '?' for any single character,
+// character classes such as '[a-z]' or '[!0-9]', and '\' to escape the next character.
+// The pattern is compiled once and panics if it is invalid.
+func Glob(pattern string) CheckFunc {
+	re := regexp.MustCompile(globToRegex(pattern))
+	return stringCheck(re.MatchString)
+}
+
+func stringCheck(fn func(s string) bool) CheckFunc {
+	return func(x interface{}) bool {
+		if s, ok := x.(string); ok {
+			return fn(s)
+		}
+
+		rv := reflect.ValueOf(x)
+		if rv.Kind() != reflect.String {
+			return false
+		}
+
+		return fn(rv.String())
+	}
+}
+
+func globToRegex(pattern string) string {
+	var sb strings.Builder
+	sb.WriteString(`^(?s:`)
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			sb.WriteString(`.*`)
+		case '?':
+			sb.WriteString(`.`)
+		case '\\':
+			i++
+			if i >= len(runes) {
+				panic(ErrInvalidGlob)
+			}
+
+			sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case '[':
+			end := i + 1
+			if end < len(runes) && (runes[end] == '!' || runes[end] == '^') {
+				end++
+			}
+
+			// A closing bracket right after the opening one is part of the class.
+			if end < len(runes) && runes[end] == ']' {
+				end++
+			}
+
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+
+			if end >= len(runes) {
+				panic(ErrInvalidGlob)
+			}
+
+			class := runes[i+1 : end]
+			sb.WriteByte('[')
+			if len(class) != 0 && class[0] == '!' {
+				sb.WriteByte('^')
+				class = class[1:]
+			}
+
+			for _, cr := range class {
+				if cr == '\\' || cr == '[' || cr == ']' {
+					sb.WriteByte('\\')
+				}
+
+				sb.WriteRune(cr)
+			}
+			sb.WriteByte(']')
+
+			i = end
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	sb.WriteString(`)$`)
+	return sb.String()
+}
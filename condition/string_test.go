@@ -0,0 +1,156 @@
+package condition_test
+
+import (
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRegex(t *testing.T) {
+	c := condition.Check(condition.Regex(`^dummy value \d+$`))
+
+	values := []interface{}{
+		dummyStringValues[0],
+		dummyStringValues[1],
+		exampleNamedString("dummy value 3"),
+		dummyIntValues[0],
+		nil,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 2}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckRegexPanicInvalidPattern(t *testing.T) {
+	assert.Panics(t, func() {
+		condition.Regex(`(`)
+	})
+}
+
+func TestCheckHasPrefix(t *testing.T) {
+	c := condition.Check(condition.HasPrefix("dummy"))
+
+	values := []interface{}{
+		dummyStringValues[0],
+		"not a dummy",
+		exampleNamedString("dummy"),
+		dummyIntValues[0],
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 2}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckHasSuffix(t *testing.T) {
+	c := condition.Check(condition.HasSuffix("(but very long)"))
+
+	values := []interface{}{
+		dummyStringValues[0],
+		dummyStringValues[1],
+		dummyIntValues[0],
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{1}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckContains(t *testing.T) {
+	c := condition.Check(condition.Contains("value 2"))
+
+	values := []interface{}{
+		dummyStringValues[0],
+		dummyStringValues[1],
+		dummyIntValues[0],
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{1}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckEqualFold(t *testing.T) {
+	c := condition.Check(condition.EqualFold("DUMMY Value 1"))
+
+	values := []interface{}{
+		dummyStringValues[0],
+		dummyStringValues[1],
+		exampleNamedString("dummy VALUE 1"),
+		dummyIntValues[0],
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 2}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckGlob(t *testing.T) {
+	c := condition.Check(condition.Glob("dummy value [!2]*"))
+
+	values := []interface{}{
+		dummyStringValues[0],
+		dummyStringValues[1],
+		"dummy value 3 with a/slash",
+		"dummy value ",
+		dummyIntValues[0],
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 2}),
+	}
+
+	testCond(t, c, test)
+
+	assert.True(t, condition.Glob(`a?c`)("abc"))
+	assert.False(t, condition.Glob(`a?c`)("abbc"))
+	assert.True(t, condition.Glob(`\*.go`)("*.go"))
+	assert.False(t, condition.Glob(`\*.go`)("main.go"))
+	assert.True(t, condition.Glob(`[]a]x`)("]x"))
+	assert.True(t, condition.Glob(`v[0-9].(1)`)("v1.(1)"))
+}
+
+func TestCheckGlobPanicInvalidPattern(t *testing.T) {
+	assert.PanicsWithError(t, condition.ErrInvalidGlob.Error(), func() {
+		condition.Glob("[abc")
+	})
+
+	assert.PanicsWithError(t, condition.ErrInvalidGlob.Error(), func() {
+		condition.Glob(`abc\`)
+	})
+}
+
+func TestFieldCheckHasPrefix(t *testing.T) {
+	c := condition.FieldCheck("Field2.Field1.Field1", condition.HasPrefix("subvalue"))
+
+	values := []interface{}{
+		dummyStructValues[0],
+		dummyStructValues[1],
+		dummyRogueStructValue,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0}),
+	}
+
+	testCond(t, c, test)
+}
@@ -0,0 +1,64 @@
+package condition
+
+import "reflect"
+
+// Dynamic type check with the given type parameter.
+// If the type parameter is an interface, the check is satisfied by any value implementing it.
+func IsType[T any]() CheckFunc {
+	return func(x interface{}) bool {
+		_, ok := x.(T)
+		return ok
+	}
+}
+
+// Exact dynamic type check with the given type.
+func OfType(typ reflect.Type) CheckFunc {
+	return func(x interface{}) bool {
+		return reflect.TypeOf(x) == typ
+	}
+}
+
+// Kind check with the given kind.
+// A nil value is of the invalid kind.
+func Kind(kind reflect.Kind) CheckFunc {
+	return func(x interface{}) bool {
+		return reflect.ValueOf(x).Kind() == kind
+	}
+}
+
+// Nil check of a value or of the nilable kinds (chan, func, interface, map, pointer, and slice).
+func IsNil() CheckFunc {
+	return func(x interface{}) bool {
+		rv := reflect.ValueOf(x)
+
+		switch rv.Kind() {
+		case reflect.Invalid:
+			return true
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+			return rv.IsNil()
+		default:
+			return false
+		}
+	}
+}
+
+// Zero value check of any type.
+func IsZero() CheckFunc {
+	return func(x interface{}) bool {
+		rv := reflect.ValueOf(x)
+		return !rv.IsValid() || rv.IsZero()
+	}
+}
+
+// Interface implementation check with the given interface type.
+// It panics if the given type is not an interface.
+func Implements(iface reflect.Type) CheckFunc {
+	if iface == nil || iface.Kind() != reflect.Interface {
+		panic(ErrNotInterface)
+	}
+
+	return func(x interface{}) bool {
+		typ := reflect.TypeOf(x)
+		return typ != nil && typ.Implements(iface)
+	}
+}
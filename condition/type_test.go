@@ -0,0 +1,177 @@
+package condition_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+	"github.com/stretchr/testify/assert"
+)
+
+type exampleStringer struct{}
+
+func (exampleStringer) String() string {
+	return "example"
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+func TestCheckIsType(t *testing.T) {
+	c := condition.Check(condition.IsType[exampleStruct]())
+
+	values := []interface{}{
+		dummyStructValues[0],
+		&dummyStructValues[0],
+		dummyRogueStructValue,
+		dummyIntValues[0],
+		nil,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckIsTypeInterface(t *testing.T) {
+	c := condition.Check(condition.IsType[fmt.Stringer]())
+
+	values := []interface{}{
+		exampleStringer{},
+		&exampleStringer{},
+		dummyStructValues[0],
+		nil,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 1}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckOfType(t *testing.T) {
+	c := condition.Check(condition.OfType(reflect.TypeOf(dummyRogueStructValue)))
+
+	values := []interface{}{
+		dummyStructValues[0],
+		dummyRogueStructValue,
+		&dummyRogueStructValue,
+		nil,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{1}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckKind(t *testing.T) {
+	c := condition.Check(condition.Kind(reflect.Struct))
+
+	values := []interface{}{
+		dummyStructValues[0],
+		&dummyStructValues[0],
+		dummyRogueStructValue,
+		dummyIntValues[0],
+		nil,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 2}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckIsNil(t *testing.T) {
+	c := condition.Check(condition.IsNil())
+
+	values := []interface{}{
+		nil,
+		(*exampleStruct)(nil),
+		[]int(nil),
+		map[string]int(nil),
+		&dummyStructValues[0],
+		[]int{},
+		0,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 1, 2, 3}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckIsZero(t *testing.T) {
+	c := condition.Check(condition.IsZero())
+
+	values := []interface{}{
+		nil,
+		0,
+		"",
+		exampleStruct{},
+		(*exampleStruct)(nil),
+		1,
+		"a",
+		dummyStructValues[0],
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 1, 2, 3, 4}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckImplements(t *testing.T) {
+	c := condition.Check(condition.Implements(stringerType))
+
+	values := []interface{}{
+		exampleStringer{},
+		&exampleStringer{},
+		dummyStructValues[0],
+		nil,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{0, 1}),
+	}
+
+	testCond(t, c, test)
+}
+
+func TestCheckImplementsPanicNotInterface(t *testing.T) {
+	assert.PanicsWithError(t, condition.ErrNotInterface.Error(), func() {
+		condition.Implements(reflect.TypeOf(dummyRogueStructValue))
+	})
+}
+
+func TestFieldCheckIsNil(t *testing.T) {
+	c := condition.FieldCheck("Field2", condition.IsNil())
+
+	values := []interface{}{
+		dummyStructValues[0],
+		dummyStructValues[1],
+		dummyStructValues[2],
+		dummyRogueStructValue,
+	}
+
+	test := CondTest{
+		Values:       values,
+		Expectations: makeExpectations(len(values), []int{1, 2}),
+	}
+
+	testCond(t, c, test)
+}
@@ -1,66 +1,56 @@
 package condition
 
-import (
-	"reflect"
-	"strings"
-)
+import "reflect"
 
 type (
-	checkCond struct {
-		fn func(x interface{}) bool
+	checkCond[T any] struct {
+		fn CheckFuncOf[T]
 	}
-	fieldCheckCond struct {
-		target []string
-		fn     func(x interface{}) bool
+	fieldCheckCond[T any] struct {
+		path fieldPath
+		fn   CheckFunc
 	}
 
-	CheckFunc func(x interface{}) bool
+	CheckFuncOf[T any] func(x T) bool
+	CheckFunc          = CheckFuncOf[interface{}]
 )
 
 // Matches to true if an element satisfies the given check function for the specified value.
 func Check(fn CheckFunc) Condition {
-	return checkCond{
+	return CheckOf(fn)
+}
+
+// Typed variant of Check.
+func CheckOf[T any](fn CheckFuncOf[T]) ConditionOf[T] {
+	return checkCond[T]{
 		fn: fn,
 	}
 }
 
-func (c checkCond) Test(mctx MatchContext) bool {
+func (c checkCond[T]) Test(mctx MatchContextOf[T]) bool {
 	return c.fn(mctx.CurrentValue())
 }
 
 // Matches to true if a struct element's value satisfies the given check function for the specified value.
-func FieldCheck(target string, fn CheckFunc) Condition {
-	return fieldCheckCond{
-		target: strings.Split(target, "."),
-		fn:     fn,
-	}
+//
+// The target is a dot-separated path, which may also index into maps, slices, and arrays,
+// such as "Items.0.Name", or match any of their elements with a wildcard, such as "Items.*.Name".
+func FieldCheck(target string, fn CheckFunc, options ...FieldOption) Condition {
+	return FieldCheckOf[interface{}](target, fn, options...)
 }
 
-func (c fieldCheckCond) Test(mctx MatchContext) bool {
-	rv := reflect.ValueOf(mctx.CurrentValue())
-
-	for i := 0; i < len(c.target); i++ {
-		switch rv.Kind() {
-		case reflect.Ptr, reflect.Interface:
-			rv = rv.Elem()
-		}
-
-		switch rv.Kind() {
-		case reflect.Struct:
-			rv = rv.FieldByName(c.target[i])
-		case reflect.Map:
-			rv = rv.MapIndex(reflect.ValueOf(c.target[i]))
-		default:
-			return false
-		}
-
-		if !rv.IsValid() {
-			return false
-		}
+// Typed variant of FieldCheck.
+func FieldCheckOf[T any](target string, fn CheckFunc, options ...FieldOption) ConditionOf[T] {
+	return fieldCheckCond[T]{
+		path: parseFieldPath(target, options),
+		fn:   fn,
 	}
+}
 
-	val := rv.Interface()
-	return c.fn(val)
+func (c fieldCheckCond[T]) Test(mctx MatchContextOf[T]) bool {
+	return testField(mctx, c.path, func(rv reflect.Value) bool {
+		return c.fn(rv.Interface())
+	})
 }
 
 // Shallow equality check with the given value.
@@ -133,3 +133,35 @@ func TestFieldCheck(t *testing.T) {
 
 	testCond(t, c, test)
 }
+
+func TestCheckOf(t *testing.T) {
+	c := condition.CheckOf(func(x int) bool {
+		return x > 350
+	})
+
+	testCondOf(t, c, dummyIntValues, makeExpectations(len(dummyIntValues), []int{0, 1, 3, 8}))
+}
+
+func TestFieldCheckOf(t *testing.T) {
+	condTarget := "Field1.Field2"
+	condVal := dummyStructValues[0].Field1.Field2
+
+	c := condition.FieldCheckOf[exampleStruct](condTarget, condition.Eq(condVal))
+
+	testCondOf(t, c, dummyStructValues, makeExpectations(len(dummyStructValues), []int{0}))
+}
+
+func TestFieldCheckOfPointer(t *testing.T) {
+	condTarget := "Field2.Field1.Field2"
+	condVal := dummyStructValues[0].Field2.Field1.Field2
+
+	c := condition.FieldCheckOf[*exampleStruct](condTarget, condition.Eq(condVal))
+
+	values := []*exampleStruct{
+		&dummyStructValues[0],
+		&dummyStructValues[1],
+		nil,
+	}
+
+	testCondOf(t, c, values, makeExpectations(len(values), []int{0}))
+}
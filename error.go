@@ -0,0 +1,68 @@
+package conma
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidGoroutines = errors.New("invalid number of goroutines")
+	ErrInvalidThreshold  = errors.New("invalid parallel threshold")
+)
+
+type (
+	// Error of a mapper failing to map an element.
+	MapError struct {
+		// The index of the element which failed to be mapped.
+		Index int
+
+		// The index of the entry whose mapper failed.
+		EntryIndex int
+
+		// The error returned by the mapper.
+		Err error
+	}
+
+	// Errors of every mapper failing to map an element, in the order they occurred.
+	MapErrors []*MapError
+)
+
+func (e *MapError) Error() string {
+	return fmt.Sprintf("element %d, entry %d: %v", e.Index, e.EntryIndex, e.Err)
+}
+
+func (e *MapError) Unwrap() error {
+	return e.Err
+}
+
+func (e MapErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Report whether any of the errors matches the target, for errors.Is.
+func (e MapErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Find the first of the errors matching the target and set the target to it, for errors.As.
+func (e MapErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
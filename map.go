@@ -1,64 +1,468 @@
 package conma
 
 import (
+	"sync"
+	"sync/atomic"
+
 	"github.com/ezraisw/conma/condition"
 	"github.com/ezraisw/conma/mapping"
 )
 
-type Entry struct {
-	// The condition to satisfy.
-	Cond condition.Condition
+type (
+	EntryOf[In, Out any] struct {
+		// The condition to satisfy.
+		Cond condition.ConditionOf[In]
 
-	// The mapper which will produce the value.
-	Mapper mapping.MapperFunc
-}
+		// The mapper which will produce the value.
+		Mapper mapping.MapperFuncOf[In, Out]
 
-type Map struct {
-	entries []Entry
-}
+		// The mapper which will produce the value from the match context,
+		// including the captures recorded by Cond.
+		// It is used instead of Mapper if set.
+		ContextMapper mapping.ContextMapperFuncOf[In, Out]
 
-// Create a new empty conditional map.
-func New() *Map {
-	return &Map{
-		entries: make([]Entry, 0),
+		// The mapper which will produce the value or fail with an error.
+		// It is used instead of Mapper if set.
+		ErrMapper mapping.ErrMapperFuncOf[In, Out]
+	}
+
+	Entry = EntryOf[interface{}, interface{}]
+
+	MapOf[In, Out any] struct {
+		entries       []EntryOf[In, Out]
+		defaultMapper mapping.MapperFuncOf[In, Out]
+		options       mapOptions
+	}
+
+	Map = MapOf[interface{}, interface{}]
+
+	mapOptions struct {
+		firstMatch    bool
+		collectErrors bool
+		bitsets       bool
+		goroutines    int
+		threshold     int
+	}
+
+	MapOption func(o *mapOptions)
+
+	// Results and errors of mapping a range of elements.
+	shardOf[Out any] struct {
+		low     int
+		high    int
+		results []ResultOf[Out]
+		errs    MapErrors
+
+		// The element being mapped, and the value of the panic which stopped the mapping, if any.
+		current    int
+		panicked   bool
+		panicValue interface{}
+	}
+
+	ResultOf[T any] struct {
+		// The index of the element which produced the value.
+		Index int
+
+		// The index of the entry which produced the value.
+		// It is DefaultEntryIndex if the value is produced by the default mapper.
+		EntryIndex int
+
+		// The value produced by the mapper.
+		Value T
 	}
+
+	Result = ResultOf[interface{}]
+)
+
+const (
+	// The entry index of results produced by the default mapper.
+	DefaultEntryIndex = -1
+
+	// The minimum number of elements to map them in parallel if WithParallel is used.
+	DefaultParallelThreshold = 1024
+)
+
+// Create a new empty conditional map.
+func New(options ...MapOption) *Map {
+	return NewOf[interface{}, interface{}](options...)
+}
+
+// Typed variant of New.
+func NewOf[In, Out any](options ...MapOption) *MapOf[In, Out] {
+	return NewWithEntriesOf(make([]EntryOf[In, Out], 0), options...)
 }
 
 // Create a conditional map with the given entries.
-func NewWithEntries(entries []Entry) *Map {
-	return &Map{
+func NewWithEntries(entries []Entry, options ...MapOption) *Map {
+	return NewWithEntriesOf(entries, options...)
+}
+
+// Typed variant of NewWithEntries.
+func NewWithEntriesOf[In, Out any](entries []EntryOf[In, Out], options ...MapOption) *MapOf[In, Out] {
+	m := &MapOf[In, Out]{
 		entries: entries,
+		options: mapOptions{
+			threshold: DefaultParallelThreshold,
+		},
+	}
+
+	for _, option := range options {
+		option(&m.options)
+	}
+
+	return m
+}
+
+// Only the first entry satisfied by an element will produce a value,
+// similar to the cases of a switch statement.
+// Entries are tried in the order they are set.
+func WithFirstMatch(firstMatch bool) MapOption {
+	return func(o *mapOptions) {
+		o.firstMatch = firstMatch
+	}
+}
+
+// Keep mapping the remaining elements when a mapper fails, collecting every error as MapErrors.
+// By default, mapping stops at the first error.
+func WithCollectErrors(collectErrors bool) MapOption {
+	return func(o *mapOptions) {
+		o.collectErrors = collectErrors
+	}
+}
+
+// Test the condition of every entry on the whole slice at once before mapping,
+// computing a bitset of the satisfying elements per entry.
+// Conditions combined with And, Or and Not are computed with bitwise operations,
+// offsets by shifting the bitsets of their subconditions,
+// and LookaroundP, Within and Memo from their results for the slice, loaded once per entry rather than per element.
+// Other conditions are tested element by element.
+//
+// The conditions of all elements are tested entry by entry before any mapper is called,
+// so when a mapper fails without WithCollectErrors, the conditions were also tested on the elements after it.
+// Entries with a context mapper are tested again on their matching elements to record the captures.
+//
+// It pays off most for many entries sharing LookaroundP, Within or Memo conditions,
+// and least when costly checks such as FieldCheck dominate.
+func WithBitsets(bitsets bool) MapOption {
+	return func(o *mapOptions) {
+		o.bitsets = bitsets
+	}
+}
+
+// Map the elements with the given number of goroutines, each mapping an equal range of the slice.
+// The results and errors are the same and in the same order as mapping sequentially,
+// but the conditions and mappers must be safe for concurrent use.
+//
+// Mappers of elements after a failing or panicking one may still be called, although their results are discarded.
+// A panic of a condition or mapper is raised again by the calling goroutine once every goroutine is done,
+// the one of the lowest element first.
+func WithParallel(goroutines int) MapOption {
+	return func(o *mapOptions) {
+		if goroutines <= 0 {
+			panic(ErrInvalidGoroutines)
+		}
+
+		o.goroutines = goroutines
+	}
+}
+
+// The minimum number of elements to map them in parallel, DefaultParallelThreshold by default.
+// Smaller slices are mapped sequentially, as starting the goroutines would outweigh the work.
+func WithParallelThreshold(threshold int) MapOption {
+	return func(o *mapOptions) {
+		if threshold < 0 {
+			panic(ErrInvalidThreshold)
+		}
+
+		o.threshold = threshold
 	}
 }
 
 // Set a new entry for the map.
-func (m *Map) Set(cond condition.Condition, mapper mapping.MapperFunc) {
-	m.entries = append(m.entries, Entry{
+func (m *MapOf[In, Out]) Set(cond condition.ConditionOf[In], mapper mapping.MapperFuncOf[In, Out]) {
+	m.entries = append(m.entries, EntryOf[In, Out]{
 		Cond:   cond,
 		Mapper: mapper,
 	})
 }
 
+// Set a new entry for the map with a mapper receiving the match context.
+func (m *MapOf[In, Out]) SetWithContext(cond condition.ConditionOf[In], mapper mapping.ContextMapperFuncOf[In, Out]) {
+	m.entries = append(m.entries, EntryOf[In, Out]{
+		Cond:          cond,
+		ContextMapper: mapper,
+	})
+}
+
+// Set a new entry for the map with a mapper which may fail.
+func (m *MapOf[In, Out]) SetWithErr(cond condition.ConditionOf[In], mapper mapping.ErrMapperFuncOf[In, Out]) {
+	m.entries = append(m.entries, EntryOf[In, Out]{
+		Cond:      cond,
+		ErrMapper: mapper,
+	})
+}
+
+// Set the mapper for elements that do not satisfy any entry.
+// Such elements are dropped from the result if no default mapper is set.
+func (m *MapOf[In, Out]) SetDefault(mapper mapping.MapperFuncOf[In, Out]) {
+	m.defaultMapper = mapper
+}
+
 // Map a slice from the list of entries.
 //
+// By default, every entry satisfied by an element produces a value.
+// Use WithFirstMatch to only produce a value from the first satisfied entry.
+// Elements that do not satisfy any entry are mapped by the default mapper, if set.
+//
 // Mapping a slice is a O(mn) operation where
 // m is the number of entries and n the number of elements in the slice.
+// Lookaround conditions created from LookaroundP or the LookBefore and LookAfter helpers
+// reuse the results of their condition across the slice and keep this bound when matching any or all elements,
+// unlike the other lookarounds which scan the elements around each element.
 //
 // It is always faster to use Go map when only equality is used.
-func (m Map) MapSlice(values []interface{}) []interface{} {
-	mapped := make([]interface{}, 0)
-	for i := range values {
-		for _, entry := range m.entries {
-			mctx := condition.MatchContext{
-				Values:       values,
-				CurrentIndex: i,
+//
+// It panics if a mapper fails. Use MapSliceE to handle the error instead.
+func (m MapOf[In, Out]) MapSlice(values []In) []Out {
+	mapped, err := m.MapSliceE(values)
+	if err != nil {
+		panic(err)
+	}
+
+	return mapped
+}
+
+// Map a slice from the list of entries, returning the error of the failing mappers.
+//
+// The error is a *MapError, or MapErrors if WithCollectErrors is used.
+func (m MapOf[In, Out]) MapSliceE(values []In) ([]Out, error) {
+	results, err := m.MapSliceIndexedE(values)
+
+	mapped := make([]Out, 0, len(results))
+	for _, result := range results {
+		mapped = append(mapped, result.Value)
+	}
+
+	return mapped, err
+}
+
+// Map a slice from the list of entries, keeping track of the element and entry of each value.
+//
+// The results are ordered in the same way as the values of MapSlice.
+//
+// It panics if a mapper fails. Use MapSliceIndexedE to handle the error instead.
+func (m MapOf[In, Out]) MapSliceIndexed(values []In) []ResultOf[Out] {
+	results, err := m.MapSliceIndexedE(values)
+	if err != nil {
+		panic(err)
+	}
+
+	return results
+}
+
+// Map a slice from the list of entries, keeping track of the element and entry of each value
+// and returning the error of the failing mappers.
+//
+// The error is a *MapError, or MapErrors if WithCollectErrors is used.
+// Failing mappers do not produce any result.
+func (m MapOf[In, Out]) MapSliceIndexedE(values []In) ([]ResultOf[Out], error) {
+	// Conditions computing their results once per slice share them across the elements.
+	cache := condition.NewCache()
+
+	var satisfied []condition.Bitset
+	if m.options.bitsets {
+		satisfied = m.testEntries(values, cache)
+	}
+
+	shards := m.shards(len(values))
+
+	// The lowest index of the elements stopping the mapping, past which the results are discarded.
+	stopAt := int64(len(values))
+
+	if len(shards) == 1 {
+		m.mapShard(&shards[0], values, cache, satisfied, &stopAt)
+	} else {
+		var wg sync.WaitGroup
+		for k := range shards {
+			wg.Add(1)
+			go func(shard *shardOf[Out]) {
+				defer wg.Done()
+
+				// Panics are raised again by the calling goroutine, as if the elements were mapped sequentially.
+				// Checked with a flag rather than the recovered value, which is nil for panic(nil).
+				completed := false
+				defer func() {
+					if !completed {
+						shard.panicked, shard.panicValue = true, recover()
+						storeMin(&stopAt, int64(shard.current))
+					}
+				}()
+
+				m.mapShard(shard, values, cache, satisfied, &stopAt)
+				completed = true
+			}(&shards[k])
+		}
+
+		wg.Wait()
+	}
+
+	results := make([]ResultOf[Out], 0)
+	var errs MapErrors
+	for _, shard := range shards {
+		if shard.panicked {
+			panic(shard.panicValue)
+		}
+
+		results = append(results, shard.results...)
+		errs = append(errs, shard.errs...)
+
+		if !m.options.collectErrors && len(errs) != 0 {
+			return results, errs[0]
+		}
+	}
+
+	if len(errs) != 0 {
+		return results, errs
+	}
+
+	return results, nil
+}
+
+// Test the condition of every entry on the elements which would be tested when mapping them one by one.
+func (m MapOf[In, Out]) testEntries(values []In, cache *condition.Cache) []condition.Bitset {
+	mctx := condition.MatchContextOf[In]{
+		Values: values,
+		Cache:  cache,
+	}
+
+	mask := condition.NewFullBitset(len(values))
+
+	satisfied := make([]condition.Bitset, len(m.entries))
+	for j, entry := range m.entries {
+		satisfied[j] = condition.TestSliceOf(entry.Cond, mctx, mask)
+
+		if m.options.firstMatch {
+			// Elements matching an entry are not tested on the later entries.
+			mask = mask.Clone()
+			mask.AndNot(satisfied[j])
+		}
+	}
+
+	return satisfied
+}
+
+// Split the indices of the slice into a range for each goroutine.
+func (m MapOf[In, Out]) shards(n int) []shardOf[Out] {
+	goroutines := m.options.goroutines
+	if n < m.options.threshold {
+		goroutines = 1
+	} else if goroutines > n {
+		goroutines = n
+	}
+
+	if goroutines <= 1 {
+		return []shardOf[Out]{{low: 0, high: n}}
+	}
+
+	shards := make([]shardOf[Out], goroutines)
+	for k := range shards {
+		shards[k].low = n * k / goroutines
+		shards[k].high = n * (k + 1) / goroutines
+	}
+
+	return shards
+}
+
+// Map the elements in the range of the shard, stopping past the index where the mapping stopped.
+func (m MapOf[In, Out]) mapShard(s *shardOf[Out], values []In, cache *condition.Cache, satisfied []condition.Bitset, stopAt *int64) {
+	s.results = make([]ResultOf[Out], 0)
+
+	for i := s.low; i < s.high; i++ {
+		// The results of elements after a failing or panicking one are discarded anyway.
+		if int64(i) > atomic.LoadInt64(stopAt) {
+			return
+		}
+
+		s.current = i
+
+		mctx := condition.MatchContextOf[In]{
+			Values:       values,
+			CurrentIndex: i,
+			Cache:        cache,
+		}
+
+		matched := false
+		for j, entry := range m.entries {
+			emctx := mctx
+			if entry.ContextMapper != nil {
+				// Only context mappers are able to receive the captures.
+				emctx.Captures = make(condition.Captures)
 			}
 
-			if entry.Cond.Test(mctx) {
-				mapped = append(mapped, entry.Mapper(mctx.CurrentValue()))
+			if satisfied != nil {
+				// Only testing the element again records the captures.
+				if !satisfied[j].Test(i) || (entry.ContextMapper != nil && !entry.Cond.Test(emctx)) {
+					continue
+				}
+			} else if !entry.Cond.Test(emctx) {
+				continue
 			}
+
+			matched = true
+
+			value, err := entry.mapValue(emctx)
+			if err != nil {
+				s.errs = append(s.errs, &MapError{
+					Index:      i,
+					EntryIndex: j,
+					Err:        err,
+				})
+
+				if !m.options.collectErrors {
+					storeMin(stopAt, int64(i))
+					return
+				}
+			} else {
+				s.results = append(s.results, ResultOf[Out]{
+					Index:      i,
+					EntryIndex: j,
+					Value:      value,
+				})
+			}
+
+			if m.options.firstMatch {
+				break
+			}
+		}
+
+		if !matched && m.defaultMapper != nil {
+			s.results = append(s.results, ResultOf[Out]{
+				Index:      i,
+				EntryIndex: DefaultEntryIndex,
+				Value:      m.defaultMapper(mctx.CurrentValue()),
+			})
+		}
+	}
+}
+
+// Store the value if it is lower than the stored one.
+func storeMin(addr *int64, value int64) {
+	for {
+		old := atomic.LoadInt64(addr)
+		if value >= old || atomic.CompareAndSwapInt64(addr, old, value) {
+			return
 		}
 	}
+}
 
-	return mapped
+func (e EntryOf[In, Out]) mapValue(mctx condition.MatchContextOf[In]) (Out, error) {
+	if e.ContextMapper != nil {
+		return e.ContextMapper(mctx), nil
+	}
+
+	if e.ErrMapper != nil {
+		return e.ErrMapper(mctx.CurrentValue())
+	}
+
+	return e.Mapper(mctx.CurrentValue()), nil
 }
@@ -1,6 +1,8 @@
 package conma_test
 
 import (
+	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/ezraisw/conma"
@@ -9,6 +11,8 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var errInvalid = errors.New("invalid")
+
 type exampleStruct struct {
 	Name    string
 	Code    int
@@ -74,3 +78,888 @@ func TestMap(t *testing.T) {
 	mapped := m.MapSlice(slice)
 	assert.Equal(t, expectedMapped, mapped)
 }
+
+func TestMapFirstMatch(t *testing.T) {
+	slice := []interface{}{
+		exampleStruct{
+			Name:    "john",
+			Code:    500,
+			Message: "Example 1",
+		},
+		exampleStruct{
+			Name:    "sebastian",
+			Code:    500,
+			Message: "Example 2",
+		},
+		exampleStruct{
+			Name:    "john",
+			Code:    700,
+			Message: "Example 3",
+		},
+		exampleStruct{
+			Name:    "sebastian",
+			Code:    700,
+			Message: "Example 4",
+		},
+	}
+
+	expectedMapped := []interface{}{
+		"john-500",
+		"500",
+		"john",
+	}
+
+	m := conma.NewWithEntries([]conma.Entry{
+		{
+			Cond: condition.And(
+				condition.FieldCheck("Name", condition.Eq("john")),
+				condition.FieldCheck("Code", condition.Eq(500)),
+			),
+			Mapper: mapping.Value("john-500"),
+		},
+		{
+			Cond:   condition.FieldCheck("Code", condition.Eq(500)),
+			Mapper: mapping.Value("500"),
+		},
+		{
+			Cond:   condition.FieldCheck("Name", condition.Eq("john")),
+			Mapper: mapping.Value("john"),
+		},
+	}, conma.WithFirstMatch(true))
+
+	mapped := m.MapSlice(slice)
+	assert.Equal(t, expectedMapped, mapped)
+
+	m = conma.NewWithEntries([]conma.Entry{
+		{
+			Cond:   condition.FieldCheck("Code", condition.Eq(500)),
+			Mapper: mapping.Value("500"),
+		},
+		{
+			Cond: condition.And(
+				condition.FieldCheck("Name", condition.Eq("john")),
+				condition.FieldCheck("Code", condition.Eq(500)),
+			),
+			Mapper: mapping.Value("john-500"),
+		},
+	}, conma.WithFirstMatch(true))
+
+	mapped = m.MapSlice(slice)
+	assert.Equal(t, []interface{}{"500", "500"}, mapped)
+}
+
+func TestMapDefault(t *testing.T) {
+	slice := []interface{}{
+		exampleStruct{
+			Name:    "john",
+			Code:    500,
+			Message: "Example 1",
+		},
+		exampleStruct{
+			Name:    "sebastian",
+			Code:    600,
+			Message: "Example 2",
+		},
+		exampleStruct{
+			Name:    "john",
+			Code:    700,
+			Message: "Example 3",
+		},
+	}
+
+	m := conma.New()
+	m.Set(condition.FieldCheck("Name", condition.Eq("john")), mapping.Value("john"))
+	m.Set(condition.FieldCheck("Code", condition.Eq(700)), mapping.Value("700"))
+	m.SetDefault(mapping.Identity())
+
+	mapped := m.MapSlice(slice)
+	assert.Equal(t, []interface{}{"john", slice[1], "john", "700"}, mapped)
+}
+
+func TestMapFirstMatchDefault(t *testing.T) {
+	slice := []interface{}{
+		exampleStruct{
+			Name:    "john",
+			Code:    500,
+			Message: "Example 1",
+		},
+		exampleStruct{
+			Name:    "sebastian",
+			Code:    700,
+			Message: "Example 2",
+		},
+		exampleStruct{
+			Name:    "john",
+			Code:    700,
+			Message: "Example 3",
+		},
+	}
+
+	m := conma.New(conma.WithFirstMatch(true))
+	m.Set(condition.FieldCheck("Name", condition.Eq("john")), mapping.Value("john"))
+	m.Set(condition.FieldCheck("Message", condition.Eq("Example 3")), mapping.Value("Example 3"))
+	m.SetDefault(mapping.Value("unknown"))
+
+	mapped := m.MapSlice(slice)
+	assert.Equal(t, []interface{}{"john", "unknown", "john"}, mapped)
+}
+
+func TestMapSliceIndexed(t *testing.T) {
+	slice := []interface{}{
+		exampleStruct{
+			Name:    "john",
+			Code:    500,
+			Message: "Example 1",
+		},
+		exampleStruct{
+			Name:    "sebastian",
+			Code:    600,
+			Message: "Example 2",
+		},
+		exampleStruct{
+			Name:    "john",
+			Code:    700,
+			Message: "Example 3",
+		},
+	}
+
+	expectedResults := []conma.Result{
+		{Index: 0, EntryIndex: 0, Value: "john"},
+		{Index: 1, EntryIndex: conma.DefaultEntryIndex, Value: slice[1]},
+		{Index: 2, EntryIndex: 0, Value: "john"},
+		{Index: 2, EntryIndex: 1, Value: "700"},
+	}
+
+	m := conma.New()
+	m.Set(condition.FieldCheck("Name", condition.Eq("john")), mapping.Value("john"))
+	m.Set(condition.FieldCheck("Code", condition.Eq(700)), mapping.Value("700"))
+	m.SetDefault(mapping.Identity())
+
+	results := m.MapSliceIndexed(slice)
+	assert.Equal(t, expectedResults, results)
+}
+
+func TestMapWithContext(t *testing.T) {
+	slice := []interface{}{
+		exampleStruct{
+			Name:    "<header>",
+			Message: "Header 1",
+		},
+		exampleStruct{
+			Name:    "john",
+			Code:    500,
+			Message: "Example 1",
+		},
+		exampleStruct{
+			Name:    "<header>",
+			Message: "Header 2",
+		},
+		exampleStruct{
+			Name:    "sebastian",
+			Code:    700,
+			Message: "Example 2",
+		},
+	}
+
+	expectedMapped := []interface{}{
+		"Header 1: Example 1",
+		"Header 2: Example 2",
+	}
+
+	m := conma.New()
+	m.SetWithContext(
+		condition.And(
+			condition.Not(condition.FieldCheck("Name", condition.Eq("<header>"))),
+			condition.Lookaround(
+				condition.P(condition.FieldCheck("Name", condition.Eq("<header>"))),
+				-1,
+				condition.WithMaxDist(1),
+			),
+		),
+		func(mctx condition.MatchContext) interface{} {
+			header := mctx.Values[mctx.CurrentIndex-1].(exampleStruct)
+			d := mctx.CurrentValue().(exampleStruct)
+			return header.Message + ": " + d.Message
+		},
+	)
+
+	mapped := m.MapSlice(slice)
+	assert.Equal(t, expectedMapped, mapped)
+}
+
+func TestMapSliceE(t *testing.T) {
+	slice := []interface{}{
+		"500",
+		"invalid",
+		"700",
+		"invalid again",
+	}
+
+	m := conma.New()
+	m.SetWithErr(condition.Check(condition.DeepEq("invalid")), func(x interface{}) (interface{}, error) {
+		return nil, errInvalid
+	})
+	m.SetWithErr(condition.Check(func(x interface{}) bool { return true }), func(x interface{}) (interface{}, error) {
+		return strconv.Atoi(x.(string))
+	})
+
+	mapped, err := m.MapSliceE(slice)
+	assert.Equal(t, []interface{}{500}, mapped)
+
+	var mapErr *conma.MapError
+	if assert.True(t, errors.As(err, &mapErr)) {
+		assert.Equal(t, 1, mapErr.Index)
+		assert.Equal(t, 0, mapErr.EntryIndex)
+		assert.True(t, errors.Is(err, errInvalid))
+	}
+
+	assert.PanicsWithError(t, err.Error(), func() {
+		m.MapSlice(slice)
+	})
+}
+
+func TestMapSliceECollectErrors(t *testing.T) {
+	slice := []interface{}{
+		"500",
+		"invalid",
+		"700",
+		"invalid again",
+	}
+
+	m := conma.New(conma.WithCollectErrors(true))
+	m.SetWithErr(condition.Check(condition.DeepEq("invalid")), func(x interface{}) (interface{}, error) {
+		return nil, errInvalid
+	})
+	m.SetWithErr(condition.Check(func(x interface{}) bool { return true }), func(x interface{}) (interface{}, error) {
+		return strconv.Atoi(x.(string))
+	})
+
+	results, err := m.MapSliceIndexedE(slice)
+	assert.Equal(t, []conma.Result{
+		{Index: 0, EntryIndex: 1, Value: 500},
+		{Index: 2, EntryIndex: 1, Value: 700},
+	}, results)
+
+	var mapErrs conma.MapErrors
+	if assert.True(t, errors.As(err, &mapErrs)) && assert.Len(t, mapErrs, 3) {
+		assert.Equal(t, 1, mapErrs[0].Index)
+		assert.Equal(t, 0, mapErrs[0].EntryIndex)
+		assert.True(t, errors.Is(mapErrs[0], errInvalid))
+
+		assert.Equal(t, 1, mapErrs[1].Index)
+		assert.Equal(t, 1, mapErrs[1].EntryIndex)
+
+		assert.Equal(t, 3, mapErrs[2].Index)
+		assert.Equal(t, 1, mapErrs[2].EntryIndex)
+	}
+
+	assert.True(t, errors.Is(err, errInvalid))
+	assert.False(t, errors.Is(err, errors.New("other")))
+
+	var numErr *strconv.NumError
+	if assert.True(t, errors.As(err, &numErr)) {
+		assert.Equal(t, "invalid", numErr.Num)
+	}
+
+	var mapErr *conma.MapError
+	if assert.True(t, errors.As(err, &mapErr)) {
+		assert.Equal(t, 1, mapErr.Index)
+	}
+}
+
+func TestMapOf(t *testing.T) {
+	slice := []exampleStruct{
+		{
+			Name:    "john",
+			Code:    500,
+			Message: "Example 1",
+		},
+		{
+			Name:    "<placeholder>",
+			Code:    0,
+			Message: "This is a placeholder for the next fields",
+		},
+		{
+			Name:    "john",
+			Code:    500,
+			Message: "Example 3",
+		},
+		{
+			Name:    "sebastian",
+			Code:    700,
+			Message: "Example 4",
+		},
+	}
+
+	m := conma.NewOf[exampleStruct, string](conma.WithFirstMatch(true))
+	m.Set(
+		condition.AndOf(
+			condition.FieldCheckOf[exampleStruct]("Name", condition.Eq("john")),
+			condition.LookBeforeAnyOf(
+				condition.CheckOf(func(x exampleStruct) bool {
+					return x.Name == "<placeholder>"
+				}),
+			),
+		),
+		func(x exampleStruct) string {
+			return x.Message
+		},
+	)
+	m.Set(
+		condition.CheckOf(func(x exampleStruct) bool {
+			return x.Name == "john"
+		}),
+		mapping.ValueOf[exampleStruct]("Doe"),
+	)
+	m.SetDefault(func(x exampleStruct) string {
+		return x.Name
+	})
+
+	mapped := m.MapSlice(slice)
+	assert.Equal(t, []string{"Doe", "<placeholder>", "Example 3", "sebastian"}, mapped)
+}
+
+func TestMapCaptures(t *testing.T) {
+	slice := []interface{}{
+		exampleStruct{
+			Name:    "john",
+			Code:    500,
+			Message: "order 1234 shipped",
+		},
+		exampleStruct{
+			Name:    "sebastian",
+			Code:    700,
+			Message: "order 5678 cancelled",
+		},
+		exampleStruct{
+			Name:    "john",
+			Code:    500,
+			Message: "unrelated",
+		},
+	}
+
+	m := conma.New()
+	m.SetWithContext(
+		condition.And(
+			condition.FieldCheck("Name", condition.Eq("john")),
+			condition.Or(
+				condition.FieldRegexCapture("Message", `^order (?P<id>\d+) cancelled$`),
+				condition.FieldRegexCapture("Message", `^order (?P<id>\d+) (?P<status>\w+)$`),
+			),
+		),
+		func(mctx condition.MatchContext) interface{} {
+			return mctx.Captures["id"] + ":" + mctx.Captures["status"]
+		},
+	)
+	m.SetWithContext(
+		condition.FieldRegexCapture("Message", `^order (?P<id>\d+) cancelled$`),
+		mapping.Capture("id"),
+	)
+
+	mapped := m.MapSlice(slice)
+	assert.Equal(t, []interface{}{"1234:shipped", "5678"}, mapped)
+}
+
+func TestMapType(t *testing.T) {
+	slice := []interface{}{
+		exampleStruct{
+			Name:    "john",
+			Code:    500,
+			Message: "Example 1",
+		},
+		"raw message",
+		exampleStruct{
+			Name:    "sebastian",
+			Code:    700,
+			Message: "Example 2",
+		},
+		404,
+	}
+
+	m := conma.New(conma.WithFirstMatch(true))
+	m.Set(
+		condition.And(
+			condition.Check(condition.IsType[exampleStruct]()),
+			condition.FieldCheck("Code", condition.Gte(600)),
+		),
+		func(x interface{}) interface{} {
+			return x.(exampleStruct).Name
+		},
+	)
+	m.Set(condition.Check(condition.IsType[exampleStruct]()), mapping.Value("struct"))
+	m.Set(condition.Check(condition.IsType[string]()), mapping.Identity())
+	m.SetDefault(mapping.Value("unknown"))
+
+	mapped := m.MapSlice(slice)
+	assert.Equal(t, []interface{}{"struct", "raw message", "sebastian", "unknown"}, mapped)
+}
+
+func TestMapWithin(t *testing.T) {
+	slice := []string{"a", "<begin>", "b", "c", "<end>", "d", "<begin>", "e", "<end>"}
+
+	m := conma.NewOf[string, string]()
+	m.Set(
+		condition.WithinOf(
+			condition.CheckOf(func(x string) bool {
+				return x == "<begin>"
+			}),
+			condition.CheckOf(func(x string) bool {
+				return x == "<end>"
+			}),
+		),
+		mapping.IdentityOf[string](),
+	)
+
+	mapped := m.MapSlice(slice)
+	assert.Equal(t, []string{"b", "c", "e"}, mapped)
+}
+
+func TestMapMemo(t *testing.T) {
+	slice := []interface{}{"john", "jane", "john", "joe"}
+
+	calls := 0
+	isJohn := condition.Memo(condition.Check(func(x interface{}) bool {
+		calls++
+		return x == "john"
+	}))
+
+	m := conma.New()
+	m.Set(isJohn, mapping.Value("Doe"))
+	m.Set(condition.Not(isJohn), mapping.Value("Other"))
+	m.Set(condition.LookBeforeAny(isJohn), mapping.Value("After"))
+
+	mapped := m.MapSlice(slice)
+	assert.Equal(t, []interface{}{"Doe", "Other", "After", "Doe", "After", "Other", "After"}, mapped)
+	assert.Equal(t, len(slice), calls)
+}
+
+func newRuleTable(options ...conma.MapOption) *conma.Map {
+	m := conma.New(options...)
+
+	for k := 0; k < 24; k++ {
+		code := k * 100
+		m.Set(
+			condition.And(
+				condition.FieldCheck("Code", condition.Gte(code)),
+				condition.Not(condition.FieldCheck("Name", condition.Eq("<placeholder>"))),
+				condition.Or(
+					condition.Prev(condition.FieldCheck("Code", condition.Lt(code))),
+					condition.LookBeforeAny(condition.FieldCheck("Name", condition.Eq("<placeholder>"))),
+				),
+			),
+			mapping.Value(k),
+		)
+	}
+
+	m.SetWithContext(
+		condition.And(
+			condition.FieldRegexCapture("Message", `^Example (?P<n>\d+)$`),
+			condition.Next(condition.FieldCheck("Code", condition.Gt(1000)), condition.WithOutOfBounds(true)),
+		),
+		mapping.Capture("n"),
+	)
+
+	return m
+}
+
+func newRuleTableSlice(n int) []interface{} {
+	names := []string{"john", "sebastian", "<placeholder>", "jane"}
+
+	slice := make([]interface{}, n)
+	for i := range slice {
+		slice[i] = exampleStruct{
+			Name:    names[(i*7)%len(names)],
+			Code:    (i * 733) % 2500,
+			Message: "Example " + strconv.Itoa(i%13),
+		}
+	}
+
+	return slice
+}
+
+func TestMapBitsets(t *testing.T) {
+	slice := newRuleTableSlice(300)
+
+	expected := newRuleTable().MapSliceIndexed(slice)
+	results := newRuleTable(conma.WithBitsets(true)).MapSliceIndexed(slice)
+	assert.Equal(t, expected, results)
+
+	expected = newRuleTable(conma.WithFirstMatch(true)).MapSliceIndexed(slice)
+	results = newRuleTable(conma.WithFirstMatch(true), conma.WithBitsets(true)).MapSliceIndexed(slice)
+	assert.Equal(t, expected, results)
+}
+
+func benchmarkMapSlice(b *testing.B, m *conma.Map) {
+	slice := newRuleTableSlice(5000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.MapSlice(slice)
+	}
+}
+
+func BenchmarkMapSlice(b *testing.B) {
+	benchmarkMapSlice(b, newRuleTable())
+}
+
+func BenchmarkMapSliceBitsets(b *testing.B) {
+	benchmarkMapSlice(b, newRuleTable(conma.WithBitsets(true)))
+}
+
+func newIntRuleTable(options ...conma.MapOption) *conma.MapOf[int, int] {
+	m := conma.NewOf[int, int](options...)
+
+	for k := 0; k < 24; k++ {
+		lo := k * 100
+		gte := condition.CheckOf(func(x int) bool {
+			return x >= lo
+		})
+		odd := condition.CheckOf(func(x int) bool {
+			return x%2 == 1
+		})
+
+		m.Set(
+			condition.AndOf(
+				gte,
+				condition.NotOf(odd),
+				condition.OrOf(
+					condition.PrevOf(condition.NotOf(gte)),
+					condition.NextOf(odd),
+					condition.LookBeforeAnyOf(condition.CheckOf(func(x int) bool {
+						return x == lo
+					})),
+				),
+			),
+			mapping.ValueOf[int](k),
+		)
+	}
+
+	return m
+}
+
+func benchmarkMapSliceInt(b *testing.B, m *conma.MapOf[int, int]) {
+	slice := make([]int, 5000)
+	for i := range slice {
+		slice[i] = (i * 733) % 2500
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.MapSlice(slice)
+	}
+}
+
+func BenchmarkMapSliceInt(b *testing.B) {
+	benchmarkMapSliceInt(b, newIntRuleTable())
+}
+
+func BenchmarkMapSliceIntBitsets(b *testing.B) {
+	benchmarkMapSliceInt(b, newIntRuleTable(conma.WithBitsets(true)))
+}
+
+// Rule table with conditions sharing the per-slice cache between goroutines as well.
+func newParallelRuleTable(options ...conma.MapOption) *conma.Map {
+	m := newRuleTable(options...)
+
+	isPlaceholder := condition.Memo(condition.FieldCheck("Name", condition.Eq("<placeholder>")))
+	m.Set(
+		condition.Within(isPlaceholder, condition.FieldCheck("Name", condition.Eq("jane"))),
+		mapping.Value(-1),
+	)
+
+	return m
+}
+
+func TestMapParallel(t *testing.T) {
+	slice := newRuleTableSlice(3000)
+	expected := newParallelRuleTable().MapSliceIndexed(slice)
+
+	for _, goroutines := range []int{1, 2, 3, 8} {
+		results := newParallelRuleTable(
+			conma.WithParallel(goroutines),
+			conma.WithParallelThreshold(0),
+		).MapSliceIndexed(slice)
+		assert.Equal(t, expected, results, "Goroutines: %d", goroutines)
+
+		results = newParallelRuleTable(
+			conma.WithParallel(goroutines),
+			conma.WithParallelThreshold(0),
+			conma.WithBitsets(true),
+		).MapSliceIndexed(slice)
+		assert.Equal(t, expected, results, "Goroutines: %d, Bitsets: true", goroutines)
+	}
+}
+
+func TestMapParallelSmallSlice(t *testing.T) {
+	slice := newRuleTableSlice(3)
+
+	expected := newParallelRuleTable().MapSliceIndexed(slice)
+	results := newParallelRuleTable(conma.WithParallel(8), conma.WithParallelThreshold(0)).MapSliceIndexed(slice)
+	assert.Equal(t, expected, results)
+
+	assert.Empty(t, newParallelRuleTable(conma.WithParallel(8), conma.WithParallelThreshold(0)).MapSlice(nil))
+}
+
+func newFailingMap(options ...conma.MapOption) *conma.MapOf[int, int] {
+	m := conma.NewOf[int, int](options...)
+	m.Set(condition.CheckOf(func(x int) bool { return true }), mapping.IdentityOf[int]())
+	m.SetWithErr(condition.CheckOf(func(x int) bool { return x%500 == 499 }), func(x int) (int, error) {
+		return 0, errInvalid
+	})
+
+	return m
+}
+
+func TestMapParallelErr(t *testing.T) {
+	slice := make([]int, 2000)
+	for i := range slice {
+		slice[i] = i
+	}
+
+	expected, expectedErr := newFailingMap().MapSliceIndexedE(slice)
+	results, err := newFailingMap(
+		conma.WithParallel(4),
+		conma.WithParallelThreshold(0),
+	).MapSliceIndexedE(slice)
+	assert.Equal(t, expected, results)
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 499, err.(*conma.MapError).Index)
+
+	expected, expectedErr = newFailingMap(conma.WithCollectErrors(true)).MapSliceIndexedE(slice)
+	results, err = newFailingMap(
+		conma.WithCollectErrors(true),
+		conma.WithParallel(4),
+		conma.WithParallelThreshold(0),
+	).MapSliceIndexedE(slice)
+	assert.Equal(t, expected, results)
+	assert.Equal(t, expectedErr, err)
+	assert.Len(t, err.(conma.MapErrors), 4)
+}
+
+func TestMapParallelPanicInvalidOptions(t *testing.T) {
+	assert.PanicsWithError(t, conma.ErrInvalidGoroutines.Error(), func() {
+		conma.New(conma.WithParallel(0))
+	})
+
+	assert.PanicsWithError(t, conma.ErrInvalidThreshold.Error(), func() {
+		conma.New(conma.WithParallelThreshold(-1))
+	})
+}
+
+func BenchmarkMapSliceParallel(b *testing.B) {
+	benchmarkMapSlice(b, newRuleTable(conma.WithParallel(4)))
+}
+
+func TestMapLookBeforeAnyMixedValues(t *testing.T) {
+	slice := []interface{}{
+		5,
+		exampleStruct{Name: "header"},
+		exampleStruct{Name: "detail", Message: "Detail 1"},
+		exampleStruct{Name: "detail", Message: "Detail 2"},
+	}
+
+	m := conma.New()
+	m.Set(
+		condition.And(
+			condition.FieldCheck("Name", condition.Eq("detail")),
+			condition.LookBeforeAny(condition.Check(func(x interface{}) bool {
+				return x.(exampleStruct).Name == "header"
+			})),
+		),
+		func(x interface{}) interface{} {
+			return x.(exampleStruct).Message
+		},
+	)
+
+	assert.Equal(t, []interface{}{"Detail 1", "Detail 2"}, m.MapSlice(slice))
+}
+
+func newSharedRuleTable(options ...conma.MapOption) *conma.MapOf[int, int] {
+	m := conma.NewOf[int, int](options...)
+
+	// Conditions computed once per slice, shared by every entry.
+	inSection := condition.WithinOf(
+		condition.CheckOf(func(x int) bool { return x%97 == 0 }),
+		condition.CheckOf(func(x int) bool { return x%89 == 0 }),
+	)
+	nearMarker := condition.LookaroundPOf(
+		condition.CheckOf(func(x int) bool { return x%13 == 0 }),
+		-1,
+		condition.WithMaxDist(8),
+	)
+	afterSmall := condition.PrevOf(condition.CheckOf(func(x int) bool { return x < 500 }))
+
+	for k := 0; k < 32; k++ {
+		k := k
+		m.Set(
+			condition.AndOf(
+				inSection,
+				condition.OrOf(nearMarker, afterSmall),
+				condition.CheckOf(func(x int) bool { return x%32 == k }),
+			),
+			mapping.ValueOf[int](k),
+		)
+	}
+
+	return m
+}
+
+func benchmarkMapSliceShared(b *testing.B, m *conma.MapOf[int, int]) {
+	slice := make([]int, 50000)
+	for i := range slice {
+		slice[i] = (i * 7919) % 10007
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.MapSlice(slice)
+	}
+}
+
+func BenchmarkMapSliceShared(b *testing.B) {
+	benchmarkMapSliceShared(b, newSharedRuleTable())
+}
+
+func BenchmarkMapSliceSharedBitsets(b *testing.B) {
+	benchmarkMapSliceShared(b, newSharedRuleTable(conma.WithBitsets(true)))
+}
+
+func TestMapBitsetsShared(t *testing.T) {
+	slice := make([]int, 3000)
+	for i := range slice {
+		slice[i] = (i * 7919) % 10007
+	}
+
+	expected := newSharedRuleTable().MapSliceIndexed(slice)
+	assert.NotEmpty(t, expected)
+	assert.Equal(t, expected, newSharedRuleTable(conma.WithBitsets(true)).MapSliceIndexed(slice))
+
+	expected = newSharedRuleTable(conma.WithFirstMatch(true)).MapSliceIndexed(slice)
+	assert.Equal(t, expected, newSharedRuleTable(conma.WithFirstMatch(true), conma.WithBitsets(true)).MapSliceIndexed(slice))
+}
+
+func newPanickingMap(panicAt map[int]bool, errAt int, options ...conma.MapOption) *conma.MapOf[int, int] {
+	m := conma.NewOf[int, int](options...)
+	m.SetWithErr(condition.CheckOf(func(x int) bool { return true }), func(x int) (int, error) {
+		if panicAt[x] {
+			panic(x)
+		}
+
+		if x == errAt {
+			return 0, errInvalid
+		}
+
+		return x, nil
+	})
+
+	return m
+}
+
+func mapRecovering(m *conma.MapOf[int, int], values []int) (recovered interface{}, err error) {
+	defer func() {
+		recovered = recover()
+	}()
+
+	_, err = m.MapSliceE(values)
+	return nil, err
+}
+
+func TestMapParallelPanic(t *testing.T) {
+	slice := make([]int, 2000)
+	for i := range slice {
+		slice[i] = i
+	}
+
+	parallel := []conma.MapOption{conma.WithParallel(4), conma.WithParallelThreshold(0)}
+
+	recovered, _ := mapRecovering(newPanickingMap(map[int]bool{1500: true}, -1, parallel...), slice)
+	assert.Equal(t, 1500, recovered)
+
+	// The panic of the lowest element is raised, like when mapping sequentially.
+	recovered, _ = mapRecovering(newPanickingMap(map[int]bool{300: true, 1500: true}, -1, parallel...), slice)
+	assert.Equal(t, 300, recovered)
+
+	recovered, _ = mapRecovering(newPanickingMap(map[int]bool{300: true, 1500: true}, -1, append(parallel, conma.WithCollectErrors(true))...), slice)
+	assert.Equal(t, 300, recovered)
+
+	// An error before the panic stops the mapping first.
+	recovered, err := mapRecovering(newPanickingMap(map[int]bool{1500: true}, 100, parallel...), slice)
+	assert.Nil(t, recovered)
+	assert.ErrorIs(t, err, errInvalid)
+
+	recovered, err = mapRecovering(newPanickingMap(map[int]bool{100: true}, 1500, parallel...), slice)
+	assert.Equal(t, 100, recovered)
+	assert.NoError(t, err)
+}
+
+func TestMapParallelPanicNil(t *testing.T) {
+	slice := make([]int, 2000)
+	for i := range slice {
+		slice[i] = i
+	}
+
+	m := conma.NewOf[int, int](conma.WithParallel(4), conma.WithParallelThreshold(0))
+	m.Set(condition.CheckOf(func(x int) bool { return true }), func(x int) int {
+		if x == 1500 {
+			panic(nil)
+		}
+
+		return x
+	})
+
+	// The panic is raised even if its value is nil, rather than returning the results before it.
+	completed := false
+	func() {
+		defer func() {
+			recover()
+		}()
+
+		m.MapSlice(slice)
+		completed = true
+	}()
+	assert.False(t, completed)
+}
+
+func TestMapParallelPanicInLookaround(t *testing.T) {
+	slice := make([]int, 2000)
+	for i := range slice {
+		slice[i] = i
+	}
+
+	m := conma.NewOf[int, int](conma.WithParallel(4), conma.WithParallelThreshold(0))
+	m.Set(
+		condition.LookaroundPOf(condition.CheckOf(func(x int) bool {
+			if x == len(slice)-1 {
+				panic("invalid")
+			}
+
+			return x < 0
+		}), 1, condition.WithAtMost(0)),
+		mapping.IdentityOf[int](),
+	)
+
+	// Every shard panics with the value raised by the condition.
+	assert.PanicsWithValue(t, "invalid", func() {
+		m.MapSlice(slice)
+	})
+}
+
+func TestMapWithinMixedValues(t *testing.T) {
+	slice := []interface{}{"open", "x", "close", 5}
+
+	for _, options := range [][]conma.MapOption{nil, {conma.WithBitsets(true)}} {
+		m := conma.New(options...)
+		m.Set(
+			condition.And(
+				condition.Check(condition.IsType[string]()),
+				condition.Within(
+					condition.Check(func(x interface{}) bool { return x.(string) == "open" }),
+					condition.Check(func(x interface{}) bool { return x.(string) == "close" }),
+				),
+			),
+			mapping.Identity(),
+		)
+
+		assert.Equal(t, []interface{}{"x"}, m.MapSlice(slice))
+	}
+}
@@ -1,11 +1,59 @@
 package mapping
 
-type MapperFunc func(x interface{}) interface{}
+import "github.com/ezraisw/conma/condition"
+
+type (
+	MapperFuncOf[In, Out any] func(x In) Out
+	MapperFunc                = MapperFuncOf[interface{}, interface{}]
+
+	// Mapper receiving the match context, giving access to the elements around the matched element.
+	ContextMapperFuncOf[In, Out any] func(mctx condition.MatchContextOf[In]) Out
+	ContextMapperFunc                = ContextMapperFuncOf[interface{}, interface{}]
+
+	// Mapper which may fail to produce a value.
+	ErrMapperFuncOf[In, Out any] func(x In) (Out, error)
+	ErrMapperFunc                = ErrMapperFuncOf[interface{}, interface{}]
+
+	// Mapper producing a single value from a span of elements.
+	SpanMapperFuncOf[In, Out any] func(span []In) Out
+	SpanMapperFunc                = SpanMapperFuncOf[interface{}, interface{}]
+)
 
 // Create a mapper that directly returns the specified value.
 // Essentially, this mapper does not care about the matched element.
 func Value(val interface{}) MapperFunc {
-	return func(x interface{}) interface{} {
+	return ValueOf[interface{}](val)
+}
+
+// Typed variant of Value.
+func ValueOf[In, Out any](val Out) MapperFuncOf[In, Out] {
+	return func(x In) Out {
 		return val
 	}
 }
+
+// Create a mapper that returns the matched element as is.
+func Identity() MapperFunc {
+	return IdentityOf[interface{}]()
+}
+
+// Typed variant of Identity.
+func IdentityOf[T any]() MapperFuncOf[T, T] {
+	return func(x T) T {
+		return x
+	}
+}
+
+// Create a mapper that returns the named capture recorded by the condition.
+func Capture(name string) ContextMapperFunc {
+	return func(mctx condition.MatchContext) interface{} {
+		return mctx.Captures[name]
+	}
+}
+
+// Typed variant of Capture.
+func CaptureOf[In any](name string) ContextMapperFuncOf[In, string] {
+	return func(mctx condition.MatchContextOf[In]) string {
+		return mctx.Captures[name]
+	}
+}
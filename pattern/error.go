@@ -0,0 +1,9 @@
+package pattern
+
+import "errors"
+
+var (
+	ErrEmptyNode       = errors.New("empty node")
+	ErrInvalidRepeat   = errors.New("invalid repeat")
+	ErrInvalidMinOrMax = errors.New("invalid min or max repeat")
+)
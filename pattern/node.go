@@ -0,0 +1,177 @@
+package pattern
+
+import "github.com/ezraisw/conma/condition"
+
+type (
+	NodeOf[T any] interface {
+		// Emit the instructions of the node into the program.
+		emit(p *program[T])
+	}
+
+	Node = NodeOf[interface{}]
+
+	condNode[T any] struct {
+		cond condition.ConditionOf[T]
+	}
+	seqNode[T any]    []NodeOf[T]
+	altNode[T any]    []NodeOf[T]
+	repeatNode[T any] struct {
+		node NodeOf[T]
+		min  int
+		max  int
+	}
+)
+
+// The maximum of a repetition without an upper bound.
+const Unbounded = -1
+
+// Matches a single element satisfying the given condition.
+//
+// The condition is tested with the whole slice as its match context,
+// so lookaround conditions are able to look outside of the matched span.
+func Cond(cond condition.Condition) Node {
+	return CondOf(cond)
+}
+
+// Typed variant of Cond.
+func CondOf[T any](cond condition.ConditionOf[T]) NodeOf[T] {
+	return condNode[T]{cond: cond}
+}
+
+// Matches the given nodes one after another.
+func Seq(nodes ...Node) Node {
+	return SeqOf(nodes...)
+}
+
+// Typed variant of Seq.
+func SeqOf[T any](nodes ...NodeOf[T]) NodeOf[T] {
+	if len(nodes) == 0 {
+		panic(ErrEmptyNode)
+	}
+
+	return seqNode[T](nodes)
+}
+
+// Matches any of the given nodes, preferring the earlier ones.
+func Alt(nodes ...Node) Node {
+	return AltOf(nodes...)
+}
+
+// Typed variant of Alt.
+func AltOf[T any](nodes ...NodeOf[T]) NodeOf[T] {
+	if len(nodes) == 0 {
+		panic(ErrEmptyNode)
+	}
+
+	return altNode[T](nodes)
+}
+
+// Matches the given node zero or more times, as many times as possible.
+func Star(node Node) Node {
+	return StarOf(node)
+}
+
+// Typed variant of Star.
+func StarOf[T any](node NodeOf[T]) NodeOf[T] {
+	return RepeatOf(node, 0, Unbounded)
+}
+
+// Matches the given node one or more times, as many times as possible.
+func Plus(node Node) Node {
+	return PlusOf(node)
+}
+
+// Typed variant of Plus.
+func PlusOf[T any](node NodeOf[T]) NodeOf[T] {
+	return RepeatOf(node, 1, Unbounded)
+}
+
+// Matches the given node zero or one time, preferring one.
+func Opt(node Node) Node {
+	return OptOf(node)
+}
+
+// Typed variant of Opt.
+func OptOf[T any](node NodeOf[T]) NodeOf[T] {
+	return RepeatOf(node, 0, 1)
+}
+
+// Matches the given node between min and max times, as many times as possible.
+// Use Unbounded as the max for no upper bound.
+func Repeat(node Node, min int, max int) Node {
+	return RepeatOf(node, min, max)
+}
+
+// Typed variant of Repeat.
+func RepeatOf[T any](node NodeOf[T], min int, max int) NodeOf[T] {
+	if node == nil {
+		panic(ErrEmptyNode)
+	}
+
+	if min < 0 || max < Unbounded {
+		panic(ErrInvalidRepeat)
+	}
+
+	if max != Unbounded && max < min {
+		panic(ErrInvalidMinOrMax)
+	}
+
+	return repeatNode[T]{
+		node: node,
+		min:  min,
+		max:  max,
+	}
+}
+
+func (n condNode[T]) emit(p *program[T]) {
+	p.add(inst[T]{op: opCond, cond: n.cond, x: len(p.insts) + 1})
+}
+
+func (n seqNode[T]) emit(p *program[T]) {
+	for _, node := range n {
+		node.emit(p)
+	}
+}
+
+func (n altNode[T]) emit(p *program[T]) {
+	jmps := make([]int, 0, len(n)-1)
+
+	for _, node := range n[:len(n)-1] {
+		split := p.add(inst[T]{op: opSplit, x: len(p.insts) + 1})
+		node.emit(p)
+		jmps = append(jmps, p.add(inst[T]{op: opJmp}))
+		p.insts[split].y = len(p.insts)
+	}
+
+	n[len(n)-1].emit(p)
+
+	for _, jmp := range jmps {
+		p.insts[jmp].x = len(p.insts)
+	}
+}
+
+func (n repeatNode[T]) emit(p *program[T]) {
+	for i := 0; i < n.min; i++ {
+		n.node.emit(p)
+	}
+
+	if n.max == Unbounded {
+		// L: split L+1, end; <node>; jmp L; end:
+		loop := p.add(inst[T]{op: opSplit, x: len(p.insts) + 1})
+		n.node.emit(p)
+		p.add(inst[T]{op: opJmp, x: loop})
+		p.insts[loop].y = len(p.insts)
+		return
+	}
+
+	// Nest the optional repetitions, (node(node)?)?, so each one is only tried after the previous matches.
+	splits := make([]int, 0, n.max-n.min)
+	for i := n.min; i < n.max; i++ {
+		splits = append(splits, p.add(inst[T]{op: opSplit, x: len(p.insts) + 1}))
+		n.node.emit(p)
+	}
+
+	for _, split := range splits {
+		p.insts[split].y = len(p.insts)
+	}
+}
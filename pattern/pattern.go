@@ -0,0 +1,312 @@
+package pattern
+
+import "github.com/ezraisw/conma/condition"
+
+type (
+	opcode int
+
+	inst[T any] struct {
+		op   opcode
+		cond condition.ConditionOf[T]
+		x    int
+		y    int
+	}
+
+	program[T any] struct {
+		insts []inst[T]
+	}
+
+	// Compiled sequence pattern, safe for concurrent use.
+	PatternOf[T any] struct {
+		prog program[T]
+	}
+
+	Pattern = PatternOf[interface{}]
+
+	// Range of elements matched by a pattern, from Start (inclusive) to End (exclusive).
+	Span struct {
+		Start int
+		End   int
+	}
+
+	// Iterator over successive non-overlapping spans of a pattern.
+	SpanIterOf[T any] struct {
+		prog  program[T]
+		mctx  condition.MatchContextOf[T]
+		pos   int
+		clist threadList
+		nlist threadList
+
+		// The searches started from the end of the match of the previous one, with the identifier of the first being base.
+		// Every search but the last has a match, which is final once the first search has no threads left.
+		searches []search
+		base     int
+
+		// The final spans not obtained yet.
+		ready []Span
+	}
+
+	SpanIter = SpanIterOf[interface{}]
+
+	search struct {
+		span    Span
+		matched bool
+	}
+
+	thread struct {
+		pc     int
+		start  int
+		search int
+	}
+
+	// Ordered set of threads, deduplicated by their instruction.
+	threadList struct {
+		threads []thread
+		sparse  []int
+	}
+)
+
+const (
+	// Consume an element satisfying the condition, then continue at x.
+	opCond opcode = iota
+
+	// Continue at both x and y, preferring x.
+	opSplit
+
+	// Continue at x.
+	opJmp
+
+	// Report a match.
+	opMatch
+)
+
+// Compile a pattern from the given node.
+//
+// The pattern is matched by simulating its NFA over the elements,
+// which takes O(mn) time where m is the size of the pattern and n the number of elements.
+func Compile(node Node) *Pattern {
+	return CompileOf(node)
+}
+
+// Typed variant of Compile.
+func CompileOf[T any](node NodeOf[T]) *PatternOf[T] {
+	if node == nil {
+		panic(ErrEmptyNode)
+	}
+
+	p := &PatternOf[T]{}
+	node.emit(&p.prog)
+	p.prog.add(inst[T]{op: opMatch})
+
+	return p
+}
+
+// Find the leftmost non-empty span of elements matching the pattern.
+//
+// Like regular expressions, alternatives are preferred in order and repetitions match as many elements as possible.
+func (p PatternOf[T]) Find(values []T) (Span, bool) {
+	return p.FindIn(condition.MatchContextOf[T]{Values: values, Cache: condition.NewCache()})
+}
+
+// Find the leftmost non-empty span of elements matching the pattern, starting at or after the given position.
+// A negative position starts at the first element.
+//
+// Unlike matching a subslice, conditions still see the elements before the position.
+func (p PatternOf[T]) FindAt(values []T, pos int) (Span, bool) {
+	return p.FindIn(condition.MatchContextOf[T]{Values: values, CurrentIndex: pos, Cache: condition.NewCache()})
+}
+
+// Find the leftmost non-empty span of elements matching the pattern, starting at or after the current index of the match context.
+//
+// The cache of the match context is shared with the conditions, so that searching the same slice again
+// does not compute their per-slice results again.
+func (p PatternOf[T]) FindIn(mctx condition.MatchContextOf[T]) (Span, bool) {
+	return p.Iter(mctx).Next()
+}
+
+// Find all successive non-overlapping and non-empty spans of elements matching the pattern.
+//
+// The spans are found in a single pass over the elements, so it takes O(mn) time as well.
+func (p PatternOf[T]) FindAll(values []T) []Span {
+	spans := make([]Span, 0)
+
+	it := p.Iter(condition.MatchContextOf[T]{Values: values, Cache: condition.NewCache()})
+	for {
+		span, ok := it.Next()
+		if !ok {
+			return spans
+		}
+
+		spans = append(spans, span)
+	}
+}
+
+// Iterate over the successive spans found by FindAll, starting at or after the current index of the match context.
+// A negative index starts at the first element.
+func (p PatternOf[T]) Iter(mctx condition.MatchContextOf[T]) *SpanIterOf[T] {
+	n := len(p.prog.insts)
+
+	pos := mctx.CurrentIndex
+	if pos < 0 {
+		pos = 0
+	}
+
+	return &SpanIterOf[T]{
+		prog:     p.prog,
+		mctx:     mctx,
+		pos:      pos,
+		clist:    newThreadList(n),
+		nlist:    newThreadList(n),
+		searches: make([]search, 1),
+	}
+}
+
+// Report whether the pattern matches the whole slice.
+func (p PatternOf[T]) MatchAll(values []T) bool {
+	insts := p.prog.insts
+	clist, nlist := newThreadList(len(insts)), newThreadList(len(insts))
+
+	mctx := condition.MatchContextOf[T]{Values: values, Cache: condition.NewCache()}
+
+	// Like the other searches, the empty slice is never matched.
+	p.prog.addThread(&clist, thread{pc: 0, start: 0})
+	for i := 0; i <= len(values) && len(clist.threads) != 0; i++ {
+		for _, t := range clist.threads {
+			switch in := insts[t.pc]; in.op {
+			case opMatch:
+				if i == len(values) && i != 0 {
+					return true
+				}
+			case opCond:
+				mctx.CurrentIndex = i
+				if i < len(values) && in.cond.Test(mctx) {
+					p.prog.addThread(&nlist, thread{pc: in.x, start: t.start})
+				}
+			}
+		}
+
+		clist, nlist = nlist, clist
+		nlist.clear()
+	}
+
+	return false
+}
+
+func (p *program[T]) add(in inst[T]) int {
+	p.insts = append(p.insts, in)
+	return len(p.insts) - 1
+}
+
+// Obtain the next span, or false if there is none left.
+func (it *SpanIterOf[T]) Next() (Span, bool) {
+	for len(it.ready) == 0 && it.pos <= len(it.mctx.Values) {
+		it.step()
+	}
+
+	if len(it.ready) == 0 {
+		return Span{}, false
+	}
+
+	span := it.ready[0]
+	it.ready = it.ready[1:]
+
+	return span, true
+}
+
+// Run the Pike VM over the element at the current position, or the end of the slice.
+//
+// Rather than searching again from the end of each match, a match of a search starts another search
+// from its end right away. Threads of a search are dropped if an earlier search has a thread at the same instruction,
+// since that thread either fails in the same way or changes the match of the earlier search, which discards the later searches.
+// This keeps at most one thread per instruction, and every element is run only once.
+func (it *SpanIterOf[T]) step() {
+	insts := it.prog.insts
+	i := it.pos
+
+	// Like regular expressions, the last search tries every start until it has a match, with a lower priority.
+	if last := len(it.searches) - 1; !it.searches[last].matched {
+		it.prog.addThread(&it.clist, thread{pc: 0, start: i, search: it.base + last})
+	}
+
+	mctx := it.mctx
+	mctx.CurrentIndex = i
+
+	for k := 0; k < len(it.clist.threads); k++ {
+		t := it.clist.threads[k]
+
+		switch in := insts[t.pc]; in.op {
+		case opMatch:
+			// Empty matches are not reported.
+			if t.start == i {
+				continue
+			}
+
+			s := t.search - it.base
+			it.searches[s] = search{span: Span{Start: t.start, End: i}, matched: true}
+
+			// Threads after this one have a lower priority, including those of the later searches,
+			// which are discarded as they were started from the previous match.
+			it.clist.truncate(k + 1)
+			it.searches = append(it.searches[:s+1], search{})
+			it.prog.addThread(&it.clist, thread{pc: 0, start: i, search: t.search + 1})
+		case opCond:
+			if i < len(it.mctx.Values) && in.cond.Test(mctx) {
+				it.prog.addThread(&it.nlist, thread{pc: in.x, start: t.start, search: t.search})
+			}
+		}
+	}
+
+	it.clist, it.nlist = it.nlist, it.clist
+	it.nlist.clear()
+	it.pos++
+
+	// The match of the first search is final once it has no threads left, which is always the case at the end.
+	for len(it.searches) > 1 && it.searches[0].matched &&
+		(len(it.clist.threads) == 0 || it.clist.threads[0].search != it.base) {
+		it.ready = append(it.ready, it.searches[0].span)
+		it.searches = it.searches[1:]
+		it.base++
+	}
+}
+
+// Add a thread to the list, following the jumps and splits.
+func (p program[T]) addThread(l *threadList, t thread) {
+	if l.has(t.pc) {
+		return
+	}
+
+	l.add(t)
+
+	switch in := p.insts[t.pc]; in.op {
+	case opJmp:
+		p.addThread(l, thread{pc: in.x, start: t.start, search: t.search})
+	case opSplit:
+		p.addThread(l, thread{pc: in.x, start: t.start, search: t.search})
+		p.addThread(l, thread{pc: in.y, start: t.start, search: t.search})
+	}
+}
+
+func newThreadList(n int) threadList {
+	return threadList{
+		threads: make([]thread, 0, n),
+		sparse:  make([]int, n),
+	}
+}
+
+func (l *threadList) has(pc int) bool {
+	i := l.sparse[pc]
+	return i < len(l.threads) && l.threads[i].pc == pc
+}
+
+func (l *threadList) add(t thread) {
+	l.sparse[t.pc] = len(l.threads)
+	l.threads = append(l.threads, t)
+}
+
+func (l *threadList) clear() {
+	l.threads = l.threads[:0]
+}
+
+func (l *threadList) truncate(n int) {
+	l.threads = l.threads[:n]
+}
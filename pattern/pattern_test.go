@@ -0,0 +1,284 @@
+package pattern_test
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+
+	"github.com/ezraisw/conma/condition"
+	"github.com/ezraisw/conma/pattern"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	isHeader = pattern.Cond(condition.Check(condition.Eq("H")))
+	isDetail = pattern.Cond(condition.Check(condition.Eq("D")))
+	isFooter = pattern.Cond(condition.Check(condition.Eq("F")))
+	isAny    = pattern.Cond(condition.Check(func(x interface{}) bool { return true }))
+)
+
+func toValues(s string) []interface{} {
+	values := make([]interface{}, 0, len(s))
+	for _, r := range s {
+		values = append(values, string(r))
+	}
+
+	return values
+}
+
+func TestFindAll(t *testing.T) {
+	p := pattern.Compile(pattern.Seq(
+		isHeader,
+		pattern.Plus(isDetail),
+		pattern.Opt(isFooter),
+	))
+
+	spans := p.FindAll(toValues("HDDFxHDxHFHDDDHD"))
+	assert.Equal(t, []pattern.Span{
+		{Start: 0, End: 4},
+		{Start: 5, End: 7},
+		{Start: 10, End: 14},
+		{Start: 14, End: 16},
+	}, spans)
+}
+
+func TestFind(t *testing.T) {
+	p := pattern.Compile(pattern.Seq(isDetail, isFooter))
+
+	span, ok := p.Find(toValues("HDDDF"))
+	assert.True(t, ok)
+	assert.Equal(t, pattern.Span{Start: 3, End: 5}, span)
+
+	_, ok = p.Find(toValues("HDDD"))
+	assert.False(t, ok)
+}
+
+func TestMatchAll(t *testing.T) {
+	p := pattern.Compile(pattern.Seq(isHeader, pattern.Star(isDetail)))
+
+	assert.True(t, p.MatchAll(toValues("HDDD")))
+	assert.True(t, p.MatchAll(toValues("H")))
+	assert.False(t, p.MatchAll(toValues("HDDF")))
+	assert.False(t, p.MatchAll(toValues("")))
+}
+
+func TestAlt(t *testing.T) {
+	// Earlier alternatives are preferred, like regular expressions.
+	p := pattern.Compile(pattern.Alt(
+		pattern.Seq(isHeader, isDetail),
+		pattern.Seq(isHeader, isDetail, isDetail),
+	))
+	assert.Equal(t, []pattern.Span{{Start: 0, End: 2}}, p.FindAll(toValues("HDD")))
+
+	p = pattern.Compile(pattern.Alt(
+		pattern.Seq(isHeader, isDetail, isDetail),
+		pattern.Seq(isHeader, isDetail),
+		isFooter,
+	))
+	assert.Equal(t, []pattern.Span{{Start: 0, End: 3}, {Start: 3, End: 5}, {Start: 5, End: 6}}, p.FindAll(toValues("HDDHDF")))
+}
+
+func TestRepeat(t *testing.T) {
+	p := pattern.Compile(pattern.Repeat(isDetail, 2, 3))
+	assert.Equal(t, []pattern.Span{{Start: 1, End: 4}, {Start: 4, End: 6}}, p.FindAll(toValues("HDDDDDHD")))
+
+	p = pattern.Compile(pattern.Repeat(isDetail, 2, pattern.Unbounded))
+	assert.Equal(t, []pattern.Span{{Start: 1, End: 6}}, p.FindAll(toValues("HDDDDDHD")))
+
+	p = pattern.Compile(pattern.Seq(isHeader, pattern.Repeat(isDetail, 0, 0), isFooter))
+	assert.Equal(t, []pattern.Span{{Start: 2, End: 4}}, p.FindAll(toValues("HDHF")))
+}
+
+func TestSkipEmptyMatches(t *testing.T) {
+	p := pattern.Compile(pattern.Star(isDetail))
+	assert.Equal(t, []pattern.Span{{Start: 1, End: 3}, {Start: 4, End: 5}}, p.FindAll(toValues("HDDHD")))
+
+	p = pattern.Compile(pattern.Star(pattern.Opt(isDetail)))
+	assert.Equal(t, []pattern.Span{{Start: 1, End: 3}}, p.FindAll(toValues("HDD")))
+}
+
+func TestLookaroundAtom(t *testing.T) {
+	// Details are only matched if a footer follows somewhere after them.
+	p := pattern.Compile(pattern.Plus(pattern.Cond(condition.And(
+		condition.Check(condition.Eq("D")),
+		condition.LookAfterAny(condition.Check(condition.Eq("F"))),
+	))))
+
+	assert.Equal(t, []pattern.Span{{Start: 1, End: 3}}, p.FindAll(toValues("HDDFHDD")))
+}
+
+func TestLinearTime(t *testing.T) {
+	// Nested repetitions which would take exponential time with backtracking.
+	p := pattern.Compile(pattern.Seq(
+		pattern.Star(pattern.Star(pattern.Alt(isDetail, pattern.Seq(isDetail, isDetail)))),
+		isFooter,
+	))
+
+	values := make([]interface{}, 0, 10000)
+	for i := 0; i < 10000; i++ {
+		values = append(values, "D")
+	}
+
+	_, ok := p.Find(values)
+	assert.False(t, ok)
+
+	values = append(values, "F")
+	span, ok := p.Find(values)
+	assert.True(t, ok)
+	assert.Equal(t, pattern.Span{Start: 0, End: len(values)}, span)
+}
+
+func TestCompileOf(t *testing.T) {
+	isEven := pattern.CondOf(condition.CheckOf(func(x int) bool { return x%2 == 0 }))
+	isOdd := pattern.CondOf(condition.CheckOf(func(x int) bool { return x%2 != 0 }))
+
+	p := pattern.CompileOf(pattern.SeqOf(isOdd, pattern.PlusOf(isEven)))
+	assert.Equal(t, []pattern.Span{{Start: 1, End: 4}, {Start: 5, End: 7}}, p.FindAll([]int{2, 1, 4, 6, 3, 5, 8, 1}))
+}
+
+func TestPanics(t *testing.T) {
+	assert.PanicsWithError(t, pattern.ErrEmptyNode.Error(), func() {
+		pattern.Seq()
+	})
+
+	assert.PanicsWithError(t, pattern.ErrEmptyNode.Error(), func() {
+		pattern.Alt()
+	})
+
+	assert.PanicsWithError(t, pattern.ErrInvalidRepeat.Error(), func() {
+		pattern.Repeat(isAny, -1, 2)
+	})
+
+	assert.PanicsWithError(t, pattern.ErrInvalidMinOrMax.Error(), func() {
+		pattern.Repeat(isAny, 3, 2)
+	})
+}
+
+func TestMatchAllPrefixAlternative(t *testing.T) {
+	// The first alternative matching a prefix of the slice does not prevent the later ones from matching all of it.
+	p := pattern.Compile(pattern.Alt(isHeader, pattern.Seq(isHeader, isDetail)))
+	assert.True(t, p.MatchAll(toValues("HD")))
+	assert.True(t, p.MatchAll(toValues("H")))
+	assert.False(t, p.MatchAll(toValues("HDD")))
+
+	p = pattern.Compile(pattern.Seq(
+		isHeader,
+		pattern.Alt(isDetail, pattern.Seq(isDetail, isDetail, isFooter)),
+	))
+	assert.True(t, p.MatchAll(toValues("HDDF")))
+	assert.False(t, p.MatchAll(toValues("HDD")))
+
+	p = pattern.Compile(pattern.Seq(pattern.Repeat(isDetail, 1, 2), pattern.Opt(isDetail)))
+	assert.True(t, p.MatchAll(toValues("DDD")))
+	assert.False(t, p.MatchAll(toValues("DDDD")))
+}
+
+// Generate a random node and the equivalent regular expression, reporting whether it matches the empty slice.
+func randomNode(r *rand.Rand, depth int) (pattern.Node, string, bool) {
+	if depth == 0 || r.Intn(4) == 0 {
+		switch r.Intn(3) {
+		case 0:
+			return isHeader, "H", false
+		case 1:
+			return isDetail, "D", false
+		default:
+			return isFooter, "F", false
+		}
+	}
+
+	// Repetitions of nodes matching the empty slice are not generated,
+	// as regular expressions stop repeating them once an iteration is empty.
+	switch r.Intn(6) {
+	case 0, 1:
+		a, ra, na := randomNode(r, depth-1)
+		b, rb, nb := randomNode(r, depth-1)
+		return pattern.Seq(a, b), "(?:" + ra + rb + ")", na && nb
+	case 2, 3:
+		a, ra, na := randomNode(r, depth-1)
+		b, rb, nb := randomNode(r, depth-1)
+		return pattern.Alt(a, b), "(?:" + ra + "|" + rb + ")", na || nb
+	case 4:
+		a, ra, na := randomNode(r, depth-1)
+		if na {
+			return a, ra, na
+		}
+
+		return pattern.Star(a), "(?:" + ra + ")*", true
+	default:
+		a, ra, na := randomNode(r, depth-1)
+		if na {
+			return a, ra, na
+		}
+
+		return pattern.Plus(a), "(?:" + ra + ")+", false
+	}
+}
+
+func TestFindAllRegexp(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for k := 0; k < 500; k++ {
+		node, expr, nullable := randomNode(r, 4)
+		if nullable {
+			// Empty matches are reported by regular expressions, but not by patterns.
+			continue
+		}
+
+		re := regexp.MustCompile(expr)
+		anchored := regexp.MustCompile("^(?:" + expr + ")$")
+		p := pattern.Compile(node)
+
+		for l := 0; l < 5; l++ {
+			s := make([]byte, r.Intn(20))
+			for i := range s {
+				s[i] = "HDF"[r.Intn(3)]
+			}
+
+			expected := make([]pattern.Span, 0)
+			for _, loc := range re.FindAllStringIndex(string(s), -1) {
+				expected = append(expected, pattern.Span{Start: loc[0], End: loc[1]})
+			}
+
+			assert.Equal(t, expected, p.FindAll(toValues(string(s))), "Pattern: %s, Values: %s", expr, s)
+			assert.Equal(t, anchored.Match(s) && len(s) != 0, p.MatchAll(toValues(string(s))), "Pattern: %s, Values: %s", expr, s)
+		}
+	}
+}
+
+func TestFindAllLinearTime(t *testing.T) {
+	calls := 0
+	isCountedDetail := pattern.Cond(condition.Check(func(x interface{}) bool {
+		calls++
+		return x == "D"
+	}))
+
+	// Every detail is a match, which is only final once the longer alternative fails at the end.
+	p := pattern.Compile(pattern.Alt(pattern.Seq(pattern.Star(isCountedDetail), isFooter), isCountedDetail))
+
+	values := make([]interface{}, 0, 100000)
+	for i := 0; i < 100000; i++ {
+		values = append(values, "D")
+	}
+
+	spans := p.FindAll(values)
+	assert.Len(t, spans, len(values))
+	assert.Equal(t, pattern.Span{Start: len(values) - 1, End: len(values)}, spans[len(spans)-1])
+
+	// Each element is tested at most once per condition of the pattern.
+	assert.LessOrEqual(t, calls, 2*len(values))
+}
+
+func TestFindAtNegativePosition(t *testing.T) {
+	p := pattern.Compile(pattern.Plus(isDetail))
+
+	span, ok := p.FindAt(toValues("DDH"), -1)
+	assert.True(t, ok)
+	assert.Equal(t, pattern.Span{Start: 0, End: 2}, span)
+
+	span, ok = p.FindIn(condition.MatchContext{Values: toValues("HD"), CurrentIndex: -5})
+	assert.True(t, ok)
+	assert.Equal(t, pattern.Span{Start: 1, End: 2}, span)
+
+	_, ok = p.FindAt(toValues("HD"), 5)
+	assert.False(t, ok)
+}
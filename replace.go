@@ -0,0 +1,120 @@
+package conma
+
+import (
+	"github.com/ezraisw/conma/condition"
+	"github.com/ezraisw/conma/mapping"
+	"github.com/ezraisw/conma/pattern"
+)
+
+type (
+	SpanEntryOf[T any] struct {
+		// The pattern of the span to match.
+		Pattern *pattern.PatternOf[T]
+
+		// The mapper which will produce the replacement of the span.
+		Mapper mapping.SpanMapperFuncOf[T, T]
+	}
+
+	SpanEntry = SpanEntryOf[interface{}]
+
+	ReplacerOf[T any] struct {
+		entries []SpanEntryOf[T]
+	}
+
+	Replacer = ReplacerOf[interface{}]
+)
+
+// Create a new empty span replacer.
+func NewReplacer() *Replacer {
+	return NewReplacerOf[interface{}]()
+}
+
+// Typed variant of NewReplacer.
+func NewReplacerOf[T any]() *ReplacerOf[T] {
+	return NewReplacerWithEntriesOf(make([]SpanEntryOf[T], 0))
+}
+
+// Create a span replacer with the given entries.
+func NewReplacerWithEntries(entries []SpanEntry) *Replacer {
+	return NewReplacerWithEntriesOf(entries)
+}
+
+// Typed variant of NewReplacerWithEntries.
+func NewReplacerWithEntriesOf[T any](entries []SpanEntryOf[T]) *ReplacerOf[T] {
+	return &ReplacerOf[T]{
+		entries: entries,
+	}
+}
+
+// Set a new entry replacing the spans matching the pattern.
+func (r *ReplacerOf[T]) SetSpan(p *pattern.PatternOf[T], mapper mapping.SpanMapperFuncOf[T, T]) {
+	r.entries = append(r.entries, SpanEntryOf[T]{
+		Pattern: p,
+		Mapper:  mapper,
+	})
+}
+
+// Set a new entry replacing the runs of consecutive elements satisfying the condition.
+func (r *ReplacerOf[T]) SetRun(cond condition.ConditionOf[T], mapper mapping.SpanMapperFuncOf[T, T]) {
+	r.SetSpan(pattern.CompileOf(pattern.PlusOf(pattern.CondOf(cond))), mapper)
+}
+
+// Replace the matching spans of a slice with the value produced by their mapper.
+// Elements outside of any matching span are kept as is.
+//
+// Like a regular expression replacement, spans are replaced from left to right without overlapping.
+// The leftmost span is replaced first, with spans of earlier entries preferred if they start at the same element.
+//
+// The spans of each entry are found in a single pass like FindAll,
+// which is only searched again if a replaced span of another entry overlaps them.
+func (r ReplacerOf[T]) ReplaceSlice(values []T) []T {
+	mctx := condition.MatchContextOf[T]{
+		Values: values,
+		Cache:  condition.NewCache(),
+	}
+
+	// The next span of each entry, which is only searched again from the current position
+	// once it is overlapped by a replaced span. Otherwise, the spans of an entry are found in a single pass.
+	iters := make([]*pattern.SpanIterOf[T], len(r.entries))
+	spans := make([]pattern.Span, len(r.entries))
+	found := make([]bool, len(r.entries))
+	for i, entry := range r.entries {
+		iters[i] = entry.Pattern.Iter(mctx)
+		spans[i], found[i] = iters[i].Next()
+	}
+
+	replaced := make([]T, 0, len(values))
+	for pos := 0; pos < len(values); {
+		next := -1
+		for i, entry := range r.entries {
+			if found[i] && spans[i].Start < pos {
+				// Spans skipped by a replaced span are followed by the leftmost spans after them.
+				for found[i] && spans[i].End <= pos {
+					spans[i], found[i] = iters[i].Next()
+				}
+
+				if found[i] && spans[i].Start < pos {
+					mctx.CurrentIndex = pos
+					iters[i] = entry.Pattern.Iter(mctx)
+					spans[i], found[i] = iters[i].Next()
+				}
+			}
+
+			if found[i] && (next < 0 || spans[i].Start < spans[next].Start) {
+				next = i
+			}
+		}
+
+		if next < 0 {
+			replaced = append(replaced, values[pos:]...)
+			break
+		}
+
+		span := spans[next]
+		replaced = append(replaced, values[pos:span.Start]...)
+		replaced = append(replaced, r.entries[next].Mapper(values[span.Start:span.End:span.End]))
+		pos = span.End
+	}
+
+	return replaced
+}
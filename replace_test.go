@@ -0,0 +1,146 @@
+package conma_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ezraisw/conma"
+	"github.com/ezraisw/conma/condition"
+	"github.com/ezraisw/conma/pattern"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceSlice(t *testing.T) {
+	slice := []interface{}{
+		exampleStruct{Name: "<header>", Message: "Header 1"},
+		exampleStruct{Name: "john", Message: "Example 1"},
+		exampleStruct{Name: "john", Message: "Example 2"},
+		"unrelated",
+		exampleStruct{Name: "<header>", Message: "Header 2"},
+		exampleStruct{Name: "sebastian", Message: "Example 3"},
+		exampleStruct{Name: "<header>", Message: "Header 3"},
+	}
+
+	isHeader := condition.FieldCheck("Name", condition.Eq("<header>"))
+	isDetail := condition.And(
+		condition.Check(condition.IsType[exampleStruct]()),
+		condition.Not(isHeader),
+	)
+
+	r := conma.NewReplacer()
+	r.SetSpan(
+		pattern.Compile(pattern.Seq(pattern.Cond(isHeader), pattern.Plus(pattern.Cond(isDetail)))),
+		func(span []interface{}) interface{} {
+			messages := make([]string, 0, len(span))
+			for _, x := range span {
+				messages = append(messages, x.(exampleStruct).Message)
+			}
+
+			return strings.Join(messages, ", ")
+		},
+	)
+
+	replaced := r.ReplaceSlice(slice)
+	assert.Equal(t, []interface{}{
+		"Header 1, Example 1, Example 2",
+		"unrelated",
+		"Header 2, Example 3",
+		slice[6],
+	}, replaced)
+}
+
+func TestReplaceSliceRun(t *testing.T) {
+	r := conma.NewReplacerOf[string]()
+	r.SetRun(
+		condition.CheckOf(func(x string) bool {
+			return x == " "
+		}),
+		func(span []string) string {
+			return " "
+		},
+	)
+
+	replaced := r.ReplaceSlice(strings.Split("a  b c   d ", ""))
+	assert.Equal(t, "a b c d ", strings.Join(replaced, ""))
+}
+
+func TestReplaceSliceEntries(t *testing.T) {
+	isDigit := condition.CheckOf(func(x string) bool {
+		return x >= "0" && x <= "9"
+	})
+	isLetter := condition.CheckOf(func(x string) bool {
+		return x >= "a" && x <= "z"
+	})
+
+	r := conma.NewReplacerWithEntriesOf([]conma.SpanEntryOf[string]{
+		{
+			Pattern: pattern.CompileOf(pattern.PlusOf(pattern.CondOf(isDigit))),
+			Mapper: func(span []string) string {
+				return "<num>"
+			},
+		},
+		{
+			// Overlaps with the spans of the first entry, which start earlier.
+			Pattern: pattern.CompileOf(pattern.SeqOf(pattern.CondOf(isDigit), pattern.PlusOf(pattern.CondOf(isLetter)))),
+			Mapper: func(span []string) string {
+				return "<unit>"
+			},
+		},
+		{
+			Pattern: pattern.CompileOf(pattern.PlusOf(pattern.CondOf(isLetter))),
+			Mapper: func(span []string) string {
+				return "<word>"
+			},
+		},
+	})
+
+	replaced := r.ReplaceSlice(strings.Split("ab 12kg, x9", ""))
+	assert.Equal(t, "<word> <num><word>, <word><num>", strings.Join(replaced, ""))
+}
+
+func TestReplaceSliceKeepsValues(t *testing.T) {
+	values := []int{1, 2, 2, 3, 2}
+
+	r := conma.NewReplacerOf[int]()
+	r.SetRun(
+		condition.CheckOf(func(x int) bool {
+			return x == 2
+		}),
+		func(span []int) int {
+			// Appending must not overwrite the elements after the span.
+			span = append(span, 0)
+			return len(span) - 1
+		},
+	)
+
+	assert.Equal(t, []int{1, 2, 3, 1}, r.ReplaceSlice(values))
+	assert.Equal(t, []int{1, 2, 2, 3, 2}, values)
+}
+
+func TestReplaceSliceLinearTime(t *testing.T) {
+	calls := 0
+	isDetail := pattern.Cond(condition.Check(func(x interface{}) bool {
+		calls++
+		return x == "D"
+	}))
+	isFooter := pattern.Cond(condition.Check(condition.Eq("F")))
+
+	r := conma.NewReplacer()
+	// Every detail is a span, which is only final once the longer alternative fails at the end.
+	r.SetSpan(
+		pattern.Compile(pattern.Alt(pattern.Seq(pattern.Star(isDetail), isFooter), isDetail)),
+		func(span []interface{}) interface{} {
+			return "d"
+		},
+	)
+
+	slice := make([]interface{}, 0, 100000)
+	for i := 0; i < 100000; i++ {
+		slice = append(slice, "D")
+	}
+
+	replaced := r.ReplaceSlice(slice)
+	assert.Len(t, replaced, len(slice))
+	assert.Equal(t, "d", replaced[len(replaced)-1])
+	assert.LessOrEqual(t, calls, 2*len(slice))
+}